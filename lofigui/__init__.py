@@ -1,3 +1,26 @@
 from .print import print
-from .markdown import markdown, html, table
-from .context import PrintContext, buffer, reset
+from .markdown import markdown, markdown_with, markdown_safe, html, html_safe, htmlf
+from .markdown import set_markdown_renderer
+from .markdown import TableConfig, table_with
+from .markdown import escape, escape_attr
+from .markdown import table, table_objects, table_cells, Cell, table_writer
+from .markdown import code_block, code_block_highlighted
+from .list import list
+from .bulma import heading, heading_html, notification, button, key_values, error
+from .bulma import TabItem, tabs, Card, image, tag, tags, pagination, stat, spinner
+from .bulma import BreadcrumbItem, breadcrumb
+from .chart import line_chart, bar_chart, TimeSeries, svg
+from .form import Form, FormValues
+from .layout import details, columns, columns_sized, modal, modal_trigger
+from .layout import Panel, accordion
+from .format import format_percent, format_thousands, format_duration
+from .export import write_csv, csv_headers, FeedItem, atom_feed, ATOM_CONTENT_TYPE
+from .log import log_line, log_table
+from .element import el, Element
+from .status import StatusLevel, status_class, status_color, status_tag
+from .context import PrintContext, buffer, reset, writer, snapshot, diff, region
+from .context import FORMAT_HTML, FORMAT_JSON, set_format, to_json
+from .context import checkpoint, rollback, render_once, capture
+from .context import on_change, notify, named, render_named
+from .context import length, is_empty, write_to
+from .context import MODE_APPEND, MODE_PREPEND, MODE_REPLACE, set_mode
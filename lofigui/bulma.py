@@ -0,0 +1,289 @@
+import html
+from typing import NamedTuple
+
+from .context import _ctx, _require_append
+from .markdown import table_cells
+
+
+def _heading(level, msg, ctx, **data):
+    if ctx is None:
+        ctx = _ctx
+    level = min(max(level, 1), 6)  # Clamp to the h1..h6 that Bulma has sizes for
+    ctx.record("heading", level=level, **data)
+    ctx.put(f'<h{level} class="title is-{level}">{msg}</h{level}>\n')
+
+
+def heading_html(level, msg="", ctx=None):
+    _heading(level, msg, ctx, html=msg)
+
+
+def heading(level, text="", ctx=None):
+    text = str(text)
+    _heading(level, html.escape(text), ctx, text=text)
+
+
+NOTIFICATION_KINDS = ("success", "info", "warning", "danger")
+
+
+def notification(kind="", message="", ctx=None):
+    if ctx is None:
+        ctx = _ctx
+    message = str(message)  # eg an exception
+    ctx.record("notification", kind=kind, message=message)
+    css = "notification"
+    if kind in NOTIFICATION_KINDS:  # Unknown kinds fall back to the default style
+        css += f" is-{kind}"
+    ctx.put(f'<div class="{css}">{html.escape(message)}</div>\n')
+
+
+def button(label, cls="", action=None, method="post", href=None, ctx=None):
+    """A Bulma button, either a link (href) or posting to a url (action)"""
+    if ctx is None:
+        ctx = _ctx
+    if action and href:
+        raise ValueError("button takes either an action or an href, not both")
+    ctx.record("button", label=label, cls=cls, action=action, method=method, href=href)
+    css = html.escape(f"button {cls}".strip())
+    label = html.escape(label)
+    if href:
+        result = f'<a class="{css}" href="{html.escape(href)}">{label}</a>\n'
+    elif action:
+        result = (
+            f'<form action="{html.escape(action)}" method="{html.escape(method)}">'
+            f'<button class="{css}" type="submit">{label}</button></form>\n'
+        )
+    else:
+        result = f'<button class="{css}">{label}</button>\n'
+    ctx.put(result)
+
+
+def key_values(pairs, title="", ctx=None):
+    """Two column table of (key, value) pairs in order, both escaped"""
+    if ctx is None:
+        ctx = _ctx
+    if title:
+        _require_append(ctx, "key_values with a title")
+        heading(4, title, ctx=ctx)
+    table_cells([[key, value] for key, value in pairs], ctx=ctx)
+
+
+class TabItem(NamedTuple):
+    key: str
+    label: str
+    href: str
+
+
+def tabs(items, active_key="", ctx=None):
+    if ctx is None:
+        ctx = _ctx
+    result = '<div class="tabs">\n  <ul>\n'
+    active_done = False  # Only ever one active tab even if keys repeat
+    for item in items:
+        active = ""
+        if not active_done and item.key == active_key:
+            active = ' class="is-active"'
+            active_done = True
+        result += (
+            f'    <li{active}><a href="{html.escape(item.href)}">'
+            f"{html.escape(item.label)}</a></li>\n"
+        )
+    result += "  </ul>\n</div>\n"
+    ctx.record(
+        "tabs",
+        items=[item._asdict() for item in items],
+        active=active_key if active_done else "",
+    )
+    ctx.put(result)
+
+
+class Card:
+    """Bulma card built up from optional header, body and footer parts"""
+
+    def __init__(self):
+        self.title = ""
+        self.body = ""
+        self.footer_items = []
+
+    def header(self, title):
+        self.title = title
+        return self
+
+    def body_html(self, msg):
+        self.body += msg  # Trusted html, not escaped
+        return self
+
+    def footer_item(self, label, href):
+        self.footer_items.append((label, href))
+        return self
+
+    def render(self):
+        result = '<div class="card">\n'
+        if self.title:
+            result += (
+                '  <header class="card-header">'
+                f'<p class="card-header-title">{html.escape(self.title)}</p></header>\n'
+            )
+        if self.body:
+            result += f'  <div class="card-content">\n{self.body}\n  </div>\n'
+        if self.footer_items:
+            result += '  <footer class="card-footer">\n'
+            for label, href in self.footer_items:
+                result += (
+                    f'    <a href="{html.escape(href)}" class="card-footer-item">'
+                    f"{html.escape(label)}</a>\n"
+                )
+            result += "  </footer>\n"
+        result += "</div>\n"
+        return result
+
+    def write(self, ctx=None):
+        if ctx is None:
+            ctx = _ctx
+        ctx.put(self.render())
+
+
+IMAGE_SIZES = ("16x16", "24x24", "32x32", "48x48", "64x64", "96x96", "128x128")
+
+
+def image(src, alt="", size="", caption="", ctx=None):
+    if ctx is None:
+        ctx = _ctx
+    css = "image"
+    if size in IMAGE_SIZES:  # Unknown sizes are left to the natural image size
+        css += f" is-{size}"
+    result = f'<figure class="{css}">\n'
+    result += f'  <img src="{html.escape(src)}" alt="{html.escape(alt)}">\n'
+    if caption:
+        result += f"  <figcaption>{html.escape(caption)}</figcaption>\n"
+    result += "</figure>\n"
+    ctx.put(result)
+
+
+BULMA_COLORS = "white black light dark primary link info success warning danger".split()
+
+
+def _tag_html(text, color="", light=False):
+    if color.startswith("is-"):
+        color = color[3:]
+    css = f"tag is-{color}" if color in BULMA_COLORS else "tag is-light"
+    if light and not css.endswith("is-light"):
+        css += " is-light"
+    return f'<span class="{css}">{html.escape(str(text))}</span>'
+
+
+def tag(text, color="", light=False, ctx=None):
+    if ctx is None:
+        ctx = _ctx
+    ctx.put(_tag_html(text, color, light) + "\n")
+
+
+def tags(items, light=False, ctx=None):
+    """Group of (text, color) tags kept together on one line"""
+    if ctx is None:
+        ctx = _ctx
+    result = '<div class="tags">\n'
+    for text, color in items:
+        result += f"  {_tag_html(text, color, light)}\n"
+    result += "</div>\n"
+    ctx.put(result)
+
+
+def _page_url(base_url, page):
+    sep = "&" if "?" in base_url else "?"
+    return html.escape(f"{base_url}{sep}page={page}")
+
+
+def pagination(current, total, base_url, ctx=None):
+    """Bulma pagination showing the first, last and pages either side of current"""
+    if ctx is None:
+        ctx = _ctx
+    if total <= 1:
+        return
+    current = min(max(current, 1), total)
+    result = '<nav class="pagination" role="navigation" aria-label="pagination">\n'
+    for css, label, page in (
+        ("pagination-previous", "Previous", current - 1),
+        ("pagination-next", "Next", current + 1),
+    ):
+        if 1 <= page <= total:
+            result += (
+                f'  <a class="{css}" href="{_page_url(base_url, page)}">{label}</a>\n'
+            )
+        else:
+            result += f'  <a class="{css} is-disabled">{label}</a>\n'
+    result += '  <ul class="pagination-list">\n'
+    near = {1, current - 1, current, current + 1, total}
+    shown = sorted(page for page in near if 1 <= page <= total)
+    last = 0
+    for page in shown:
+        if page > last + 1:
+            result += '    <li><span class="pagination-ellipsis">&hellip;</span></li>\n'
+        if page == current:
+            result += (
+                f'    <li><a class="pagination-link is-current" aria-current="page">'
+                f"{page}</a></li>\n"
+            )
+        else:
+            result += (
+                '    <li><a class="pagination-link"'
+                f' href="{_page_url(base_url, page)}">{page}</a></li>\n'
+            )
+        last = page
+    result += "  </ul>\n</nav>\n"
+    ctx.put(result)
+
+
+TRENDS = {"up": "&#9650;", "down": "&#9660;"}
+
+
+def stat(label, value, color="", trend="", ctx=None):
+    """Big number tile, color is a class for the value eg has-text-success"""
+    if ctx is None:
+        ctx = _ctx
+    css = html.escape(f"title is-2 {color}".strip())
+    arrow = ""
+    if trend in TRENDS:
+        arrow = f' <span class="stat-trend stat-trend-{trend}">{TRENDS[trend]}</span>'
+    result = '<div class="box has-text-centered">\n'
+    result += f'  <p class="{css}">{html.escape(str(value))}{arrow}</p>\n'
+    result += f'  <p class="heading">{html.escape(label)}</p>\n'
+    result += "</div>\n"
+    ctx.put(result)
+
+
+def spinner(label="Working", cls="is-white", ctx=None):
+    """Static Bulma loading button to show while an action is in progress"""
+    if ctx is None:
+        ctx = _ctx
+    css = html.escape(f"button is-loading is-static {cls}".strip())
+    ctx.put(f'<span class="{css}">{html.escape(label)}</span>\n')
+
+
+class BreadcrumbItem(NamedTuple):
+    label: str
+    href: str = ""
+
+
+def breadcrumb(items, ctx=None):
+    """Breadcrumb trail, the last item is the current page so has no link"""
+    if ctx is None:
+        ctx = _ctx
+    if not items:
+        return
+    result = '<nav class="breadcrumb" aria-label="breadcrumbs">\n  <ul>\n'
+    for item in items[:-1]:
+        result += (
+            f'    <li><a href="{html.escape(item.href)}">'
+            f"{html.escape(item.label)}</a></li>\n"
+        )
+    result += (
+        '    <li class="is-active"><a aria-current="page">'
+        f"{html.escape(items[-1].label)}</a></li>\n"
+    )
+    result += "  </ul>\n</nav>\n"
+    ctx.put(result)
+
+
+def error(err, ctx=None):
+    """Show an exception (or any message) as a red notification"""
+    notification("danger", str(err), ctx=ctx)
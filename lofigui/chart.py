@@ -0,0 +1,124 @@
+import html
+from collections import deque
+
+from .context import _ctx
+
+# Simple self contained svg charts, for anything fancier use pygal (see example 02)
+PAD = 30  # Space around the plot for the title and labels
+
+
+def _svg_tag(view_box, max_width=None):
+    # Scales to fit its container, optionally no wider than max_width pixels
+    style = "width:100%;height:auto"
+    if max_width:
+        style = f"max-width:{max_width}px;{style}"
+    return (
+        f'<svg xmlns="http://www.w3.org/2000/svg" viewBox="{html.escape(view_box)}"'
+        f' style="{style}">\n'
+    )
+
+
+def svg(inner, view_box, max_width=None, ctx=None):
+    """Wrap svg body content in a responsive svg element"""
+    if ctx is None:
+        ctx = _ctx
+    ctx.put(_svg_tag(view_box, max_width) + inner + "\n</svg>\n")
+
+
+def _svg_start(width, height, title):
+    result = _svg_tag(f"0 0 {width} {height}")
+    if title:
+        result += (
+            f'  <text x="{width / 2:g}" y="{PAD / 2 + 5:g}" text-anchor="middle">'
+            f"{html.escape(title)}</text>\n"
+        )
+    return result
+
+
+def _scale(values):
+    low = min(min(values), 0)
+    high = max(max(values), 0)
+    if high == low:
+        high = low + 1  # Avoid divide by zero with flat data
+    return low, high
+
+
+def line_chart(series, width=600, height=300, title="", color="#3273dc", ctx=None):
+    if ctx is None:
+        ctx = _ctx
+    result = _svg_start(width, height, title)
+    if series:
+        low, high = _scale(series)
+        step = (width - 2 * PAD) / max(len(series) - 1, 1)
+        points = []
+        for i, v in enumerate(series):
+            x = PAD + i * step
+            y = height - PAD - (v - low) / (high - low) * (height - 2 * PAD)
+            points.append(f"{x:.1f},{y:.1f}")
+        result += (
+            f'  <polyline fill="none" stroke="{html.escape(color)}" stroke-width="2"'
+            f' points="{" ".join(points)}"/>\n'
+        )
+    result += "</svg>\n"
+    ctx.put(result)
+
+
+def bar_chart(
+    values, labels=[], width=600, height=300, title="", color="#3273dc", ctx=None
+):
+    if ctx is None:
+        ctx = _ctx
+    result = _svg_start(width, height, title)
+    if values:
+        low, high = _scale(values)
+        slot = (width - 2 * PAD) / len(values)
+        zero = height - PAD - (0 - low) / (high - low) * (height - 2 * PAD)
+        for i, v in enumerate(values):
+            y = height - PAD - (v - low) / (high - low) * (height - 2 * PAD)
+            x = PAD + i * slot + slot * 0.1
+            result += (
+                f'  <rect x="{x:.1f}" y="{min(y, zero):.1f}" width="{slot * 0.8:.1f}"'
+                f' height="{abs(zero - y):.1f}" fill="{html.escape(color)}"/>\n'
+            )
+            if i < len(labels):
+                result += (
+                    f'  <text x="{x + slot * 0.4:.1f}" y="{height - PAD / 2 + 5:g}"'
+                    f' text-anchor="middle">{html.escape(str(labels[i]))}</text>\n'
+                )
+    result += "</svg>\n"
+    ctx.put(result)
+
+
+class TimeSeries:
+    """Keeps the last max_points values for plotting, older values are dropped"""
+
+    def __init__(self, max_points=100):
+        self.points = deque(maxlen=max_points)
+
+    def add(self, value):
+        self.points.append(value)
+
+    def values(self):
+        return [*self.points]
+
+    def render_svg(self, width=200, height=40, color="#3273dc", ctx=None):
+        """Sparkline scaled to the range of the data rather than from zero"""
+        if ctx is None:
+            ctx = _ctx
+        result = _svg_tag(f"0 0 {width} {height}")
+        if self.points:
+            low, high = min(self.points), max(self.points)
+            if high == low:
+                high = low + 1
+            step = width / max(len(self.points) - 1, 1)
+            points = []
+            for i, v in enumerate(self.points):
+                y = height - (v - low) / (high - low) * height
+                points.append(f"{i * step:.1f},{y:.1f}")
+            result += (
+                f'  <polyline fill="none" stroke="{html.escape(color)}"'
+                ' stroke-width="1.5"'
+                f' points="{" ".join(points)}"/>\n'
+            )
+        result += "</svg>\n"
+        ctx.put(result)
@@ -1,10 +1,39 @@
 import asyncio
+import codecs
+import html
+import json
+import logging
+import threading
+from typing import NamedTuple
+
+logger = logging.getLogger(__name__)
+
+FORMAT_HTML = "html"
+FORMAT_JSON = "json"  # Also records structured elements alongside the html
+
+# Where each output call goes in the buffer.  Helpers that write in several pieces
+# (region, details, columns, modal, table_writer, writer, key_values with a title)
+# and checkpoint, rollback, capture and render_once raise ValueError unless
+# appending.
+MODE_APPEND = "append"
+MODE_PREPEND = "prepend"  # Newest first eg for logs
+MODE_REPLACE = "replace"  # Only the latest output is kept
+MODES = (MODE_APPEND, MODE_PREPEND, MODE_REPLACE)
 
 
 class PrintContext:
     def __init__(self):
         self.queue = asyncio.Queue()
         self.buffer = ""  # This is a results buffer
+        self.format = FORMAT_HTML
+        self.mode = MODE_APPEND
+        self.elements = []  # Structured copy of the output in json format
+        self.listeners = []
+        self.log_lines = []  # (time, level, message) from log_line
+        self.regions = {}  # Named buffers, each its own PrintContext
+        self.markdown_renderer = None  # None uses python-markdown
+        self._notify_pending = False
+        self._notify_lock = threading.Lock()
 
     def read(self):
         if self.queue.empty():
@@ -12,15 +41,119 @@ class PrintContext:
         response = ""
         while not self.queue.empty():
             # Get a "work item" out of the queue.
-            response += self.queue.get_nowait()
+            item = self.queue.get_nowait()
             self.queue.task_done()
-        self.buffer += response
+            if self.mode == MODE_PREPEND:
+                response = item + response
+            elif self.mode == MODE_REPLACE:
+                response = item
+                self.buffer = ""
+            else:
+                response += item
+        if self.mode == MODE_PREPEND:
+            self.buffer = response + self.buffer
+        else:
+            self.buffer += response
+
+    def put(self, msg):
+        self.queue.put_nowait(msg)
+        self.notify()
+
+    def on_change(self, fn):
+        """Call fn after output changes, eg to push updates to a browser"""
+        if fn is not None:
+            self.listeners.append(fn)
+
+    def notify(self):
+        if not self.listeners:
+            return
+        # Coalesce a burst of output into one call
+        with self._notify_lock:
+            if self._notify_pending:
+                return
+            self._notify_pending = True
+        try:
+            loop = asyncio.get_running_loop()
+        except RuntimeError:
+            # No event loop so use a thread, a slow listener must not block output
+            timer = threading.Timer(0, self._fire)
+            timer.daemon = True
+            timer.start()
+            return
+        loop.call_soon(self._fire)
+
+    def _fire(self):
+        with self._notify_lock:
+            self._notify_pending = False
+        for fn in list(self.listeners):
+            try:
+                fn()
+            except Exception:
+                logger.exception("on_change listener failed")
+
+    def writer(self):
+        return Writer(self)
+
+    def named(self, name):
+        """A separate buffer eg for one htmx fragment, pass it as ctx to output to it"""
+        if name not in self.regions:
+            region = PrintContext()
+            # Output to a region should look the same as output to its parent
+            region.format = self.format
+            region.mode = self.mode
+            region.markdown_renderer = self.markdown_renderer
+            self.regions[name] = region
+        return self.regions[name]
+
+    def set_mode(self, mode):
+        if mode not in MODES:
+            raise ValueError(f"unknown mode {mode!r}, expected one of {MODES}")
+        self.read()  # Output so far is placed using the old mode
+        self.mode = mode
+
+    def set_format(self, format):
+        self.format = format
+
+    def record(self, element, **data):
+        if self.format == FORMAT_JSON:
+            self.elements.append({"type": element, **data})
+
+    def json(self):
+        return json.dumps(self.elements)
+
+
+class Writer:
+    """File like adapter so libraries that write to a stream can output raw html"""
+
+    def __init__(self, ctx):
+        self.ctx = ctx
+        # Keeps a multi byte character split across writes until it is complete
+        self.decoder = codecs.getincrementaldecoder("utf-8")()
+
+    def write(self, data):
+        """Returns the number of bytes or characters written, matching the input"""
+        _require_append(self.ctx, "writer")  # Callers such as print write in pieces
+        n = len(data)
+        if isinstance(data, (bytes, bytearray)):
+            data = self.decoder.decode(data)
+            if not data:
+                return n
+        self.ctx.put(data)
+        return n
+
+    def flush(self):
+        pass
 
 
 # Slightly more involved but allows both single threaded use and option multithreaded
 _ctx = PrintContext()
 
 
+def _require_append(ctx, what):
+    if ctx.mode != MODE_APPEND:
+        raise ValueError(f"{what} needs MODE_APPEND not {ctx.mode!r}")
+
+
 def buffer(ctx=None):
     if ctx is None:
         ctx = _ctx
@@ -31,4 +164,137 @@ def buffer(ctx=None):
 def reset(ctx=None):
     if ctx is None:
         ctx = _ctx
+    ctx.read()  # Output still queued is discarded too
     ctx.buffer = ""
+    ctx.elements = []
+    ctx.log_lines = []
+    for region in ctx.regions.values():
+        reset(region)  # Kept so fragments already handed out still work
+    ctx.notify()
+
+
+def writer(ctx=None):
+    if ctx is None:
+        ctx = _ctx
+    return ctx.writer()
+
+
+def snapshot(ctx=None):
+    return buffer(ctx)
+
+
+def diff(prev, ctx=None):
+    """Compare with an earlier snapshot, returns (changed, current html)"""
+    current = buffer(ctx)
+    return current != prev, current
+
+
+def region(id, fn, ctx=None):
+    """Wrap whatever fn outputs in a div so it can be targeted on its own"""
+    if ctx is None:
+        ctx = _ctx
+    _require_append(ctx, "region")
+    ctx.put(f'<div id="lofi-{html.escape(id)}">\n')
+    fn()
+    ctx.put("</div>\n")
+
+
+def set_format(format, ctx=None):
+    if ctx is None:
+        ctx = _ctx
+    ctx.set_format(format)
+
+
+def to_json(ctx=None):
+    if ctx is None:
+        ctx = _ctx
+    return ctx.json()
+
+
+class Checkpoint(NamedTuple):
+    output: int  # Length of the html buffer
+    elements: int  # Number of json elements recorded
+
+
+def checkpoint(ctx=None):
+    """Mark the current end of the output so it can be rolled back to"""
+    if ctx is None:
+        ctx = _ctx
+    _require_append(ctx, "checkpoint")
+    return Checkpoint(len(buffer(ctx)), len(ctx.elements))
+
+
+def rollback(cp, ctx=None):
+    """Discard everything output since the checkpoint cp"""
+    if ctx is None:
+        ctx = _ctx
+    _require_append(ctx, "rollback")
+    ctx.read()
+    ctx.buffer = ctx.buffer[: cp.output]
+    del ctx.elements[cp.elements :]
+    ctx.notify()
+
+
+def render_once(fn, ctx=None):
+    """Run fn in a fresh buffer and return its output, leaving the buffer empty so
+    nothing can leak into the next render"""
+    if ctx is None:
+        ctx = _ctx
+    _require_append(ctx, "render_once")
+    ctx.read()  # Anything still queued belongs to an earlier render
+    reset(ctx)
+    fn()
+    result = buffer(ctx)
+    reset(ctx)
+    return result
+
+
+def capture(fn, ctx=None):
+    """Return just what fn outputs, leaving the buffer as it was before"""
+    cp = checkpoint(ctx)
+    fn()
+    result = buffer(ctx)[cp.output :]
+    rollback(cp, ctx)
+    return result
+
+
+def on_change(fn, ctx=None):
+    if ctx is None:
+        ctx = _ctx
+    ctx.on_change(fn)
+
+
+def notify(ctx=None):
+    if ctx is None:
+        ctx = _ctx
+    ctx.notify()
+
+
+def named(name, ctx=None):
+    if ctx is None:
+        ctx = _ctx
+    return ctx.named(name)
+
+
+def render_named(name, ctx=None):
+    return buffer(named(name, ctx))
+
+
+def length(ctx=None):
+    return len(buffer(ctx))
+
+
+def is_empty(ctx=None):
+    """True if nothing has been output since the last reset"""
+    return length(ctx) == 0
+
+
+def write_to(f, ctx=None):
+    """Write the output to a file like f, returns the number of characters written"""
+    return f.write(buffer(ctx))
+
+
+def set_mode(mode, ctx=None):
+    if ctx is None:
+        ctx = _ctx
+    ctx.set_mode(mode)
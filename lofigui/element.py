@@ -0,0 +1,32 @@
+import html
+
+VOID_ELEMENTS = set("area br col hr img input link meta source wbr".split())
+
+
+class Element(str):
+    """Html built by el, it is not escaped again when used as a child"""
+
+
+def el(tag, attrs=None, *children):
+    """Build html without string concatenation eg
+
+    lg.html(el("div", {"class": "box"}, el("p", None, "Level ", level)))
+
+    Attribute values and plain children are escaped, a True attribute is written
+    bare and False or None leaves it out."""
+    result = f"<{tag}"
+    for name, value in (attrs or {}).items():
+        if value is True:
+            result += f" {name}"
+        elif value is not None and value is not False:
+            result += f' {name}="{html.escape(str(value))}"'
+    result += ">"
+    if tag in VOID_ELEMENTS:
+        return Element(result)
+    for child in children:
+        if isinstance(child, Element):
+            result += child
+        else:
+            result += html.escape(str(child), quote=False)
+    result += f"</{tag}>"
+    return Element(result)
@@ -0,0 +1,71 @@
+import csv
+import datetime
+from typing import NamedTuple
+from xml.sax.saxutils import escape, quoteattr
+
+ATOM_CONTENT_TYPE = "application/atom+xml; charset=utf-8"
+
+
+def write_csv(f, header, rows):
+    """RFC 4180 csv of the same header and rows a table takes, to any file like f"""
+    writer = csv.writer(f, lineterminator="\r\n")
+    if header:
+        writer.writerow(header)
+    writer.writerows(rows)
+
+
+def csv_headers(filename):
+    """Response headers for a csv download eg with fastapi
+
+    Response(content, headers=lg.csv_headers("notes.csv"))
+    """
+    filename = filename.replace('"', "").replace("\r", "").replace("\n", "")
+    return {
+        "Content-Type": "text/csv; charset=utf-8",
+        "Content-Disposition": f'attachment; filename="{filename}"',
+    }
+
+
+class FeedItem(NamedTuple):
+    title: str
+    link: str
+    description: str
+    published: datetime.datetime
+
+
+def _utc(when):
+    if when.tzinfo is None:
+        when = when.replace(tzinfo=datetime.timezone.utc)  # Naive times taken as utc
+    return when.astimezone(datetime.timezone.utc)
+
+
+def _rfc3339(when):
+    return _utc(when).strftime("%Y-%m-%dT%H:%M:%SZ")
+
+
+def atom_feed(title, link, items, author=None):
+    """Atom xml for items, serve it with ATOM_CONTENT_TYPE.  Atom needs an author,
+    None uses the title"""
+    if items:
+        updated = max(_utc(item.published) for item in items)
+    else:
+        updated = datetime.datetime.now(datetime.timezone.utc)
+    if author is None:
+        author = title
+    result = '<?xml version="1.0" encoding="utf-8"?>\n'
+    result += '<feed xmlns="http://www.w3.org/2005/Atom">\n'
+    result += f"  <title>{escape(title)}</title>\n"
+    result += f"  <id>{escape(link)}</id>\n"
+    result += f"  <link href={quoteattr(link)}/>\n"
+    result += f"  <author><name>{escape(author)}</name></author>\n"
+    result += f"  <updated>{_rfc3339(updated)}</updated>\n"
+    for item in items:
+        result += "  <entry>\n"
+        result += f"    <title>{escape(item.title)}</title>\n"
+        result += f"    <id>{escape(item.link)}</id>\n"
+        result += f"    <link href={quoteattr(item.link)}/>\n"
+        result += f"    <updated>{_rfc3339(item.published)}</updated>\n"
+        result += f"    <summary>{escape(item.description)}</summary>\n"
+        result += "  </entry>\n"
+    result += "</feed>\n"
+    return result
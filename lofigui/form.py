@@ -0,0 +1,105 @@
+import html
+
+from .context import _ctx
+
+
+def _attr(value):
+    return html.escape(str(value), quote=True)
+
+
+class Form:
+    """Builds a Bulma form, eg
+
+    f = Form("/create", "post")
+    f.text("note", "Note", "What to remember", required=True)
+    f.submit("Create")
+    f.write()
+    """
+
+    def __init__(self, action, method="post"):
+        self.action = action
+        self.method = method
+        self.fields = []
+
+    def _input(self, kind, name, label, placeholder="", required=False):
+        result = '  <div class="field">\n'
+        result += (
+            f'    <label class="label" for="{_attr(name)}">'
+            f"{html.escape(label)}</label>\n"
+        )
+        result += '    <div class="control">\n'
+        result += (
+            f'      <input class="input" type="{kind}"'
+            f' id="{_attr(name)}" name="{_attr(name)}"'
+        )
+        if placeholder:
+            result += f' placeholder="{_attr(placeholder)}"'
+        if required:
+            result += " required"
+        result += ">\n"
+        result += "    </div>\n"
+        result += "  </div>\n"
+        self.fields.append(result)
+        return self
+
+    def text(self, name, label, placeholder="", required=False):
+        return self._input("text", name, label, placeholder, required)
+
+    def number(self, name, label, required=False):
+        return self._input("number", name, label, required=required)
+
+    def submit(self, label="Submit", cls="is-primary"):
+        result = '  <div class="field">\n'
+        result += '    <div class="control">\n'
+        result += (
+            f'      <button class="button {_attr(cls)}" type="submit">'
+            f"{html.escape(label)}</button>\n"
+        )
+        result += "    </div>\n"
+        result += "  </div>\n"
+        self.fields.append(result)
+        return self
+
+    def render(self):
+        result = f'<form action="{_attr(self.action)}" method="{_attr(self.method)}">\n'
+        result += "".join(self.fields)
+        result += "</form>\n"
+        return result
+
+    def write(self, ctx=None):
+        if ctx is None:
+            ctx = _ctx
+        ctx.put(self.render())
+
+
+class FormValues:
+    """Checks submitted form fields and collects the problems, eg
+
+    fv = FormValues(await request.form())
+    note_id, ok = fv.int("note_id")
+    """
+
+    def __init__(self, data):
+        self.data = data
+        self._errors = []
+
+    def int(self, name):
+        value = self.data.get(name, "")
+        if value == "":
+            self._errors.append(f"{name} is missing")
+            return 0, False
+        try:
+            return int(value), True
+        except ValueError:
+            self._errors.append(f"{name} must be a whole number, not {value!r}")
+            return 0, False
+
+    def non_empty(self, name):
+        value = str(self.data.get(name, "")).strip()
+        if not value:
+            self._errors.append(f"{name} must not be empty")
+            return "", False
+        return value, True
+
+    def errors(self):
+        return self._errors
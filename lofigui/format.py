@@ -0,0 +1,29 @@
+import datetime
+
+# Plain string formatters for use before print etc
+
+
+def format_percent(v, decimals=1):
+    if round(v, decimals) == 0:
+        v = 0.0  # Avoid -0.0% for small negative values
+    return f"{v:.{decimals}f}%"
+
+
+def format_thousands(n):
+    return f"{n:,}"
+
+
+def format_duration(d):
+    """Humanised duration eg 2m 5s, d is a timedelta or seconds"""
+    if isinstance(d, datetime.timedelta):
+        d = d.total_seconds()
+    sign = "-" if d <= -1 else ""  # Under a second rounds to 0s not -0s
+    seconds = int(abs(d))  # Truncated to whole seconds
+    parts = []
+    for unit, size in (("d", 86400), ("h", 3600), ("m", 60)):
+        if seconds >= size:
+            parts.append(f"{seconds // size}{unit}")
+            seconds %= size
+    if seconds or not parts:
+        parts.append(f"{seconds}s")
+    return sign + " ".join(parts)
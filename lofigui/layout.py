@@ -0,0 +1,96 @@
+import html
+from typing import NamedTuple
+
+from .context import _ctx, _require_append
+
+# Helpers that wrap the output of callbacks, the callbacks print as normal so it
+# is the order of the queue that puts their output inside the wrapper.
+
+
+def details(summary, fn, open=False, ctx=None):
+    if ctx is None:
+        ctx = _ctx
+    _require_append(ctx, "details")
+    attr = " open" if open else ""
+    ctx.put(f"<details{attr}><summary>{html.escape(summary)}</summary>\n")
+    fn()
+    ctx.put("</details>\n")
+
+
+def columns_sized(sizes, *cols, ctx=None):
+    """Columns where sizes gives the Bulma is-N width (1-12) of each column, any
+    missing or out of range size leaves that column to share the space"""
+    if ctx is None:
+        ctx = _ctx
+    _require_append(ctx, "columns_sized")
+    ctx.put('<div class="columns">\n')
+    for i, fn in enumerate(cols):
+        css = "column"
+        if i < len(sizes) and 1 <= sizes[i] <= 12:
+            css += f" is-{sizes[i]}"
+        ctx.put(f'<div class="{css}">\n')
+        fn()
+        ctx.put("</div>\n")
+    ctx.put("</div>\n")
+
+
+def columns(*cols, ctx=None):
+    columns_sized([], *cols, ctx=ctx)
+
+
+def modal(id, title, fn, ctx=None):
+    """Bulma modal card, shown when the url fragment is #id so no javascript is
+    needed.  Open it with modal_trigger and close it with the x or background"""
+    if ctx is None:
+        ctx = _ctx
+    _require_append(ctx, "modal")
+    id = html.escape(id)
+    result = f"<style>#{id}:target {{ display: flex; }}</style>\n"
+    result += f'<div class="modal" id="{id}">\n'
+    result += '  <a class="modal-background" href="#"></a>\n'
+    result += '  <div class="modal-card">\n'
+    result += '    <header class="modal-card-head">\n'
+    result += f'      <p class="modal-card-title">{html.escape(title)}</p>\n'
+    result += '      <a class="delete" aria-label="close" href="#"></a>\n'
+    result += "    </header>\n"
+    result += '    <section class="modal-card-body">\n'
+    ctx.put(result)
+    fn()
+    ctx.put("    </section>\n  </div>\n</div>\n")
+
+
+def modal_trigger(id, label, ctx=None):
+    if ctx is None:
+        ctx = _ctx
+    ctx.put(
+        f'<a class="button" href="#{html.escape(id)}">{html.escape(label)}</a>\n'
+    )
+
+
+class Panel(NamedTuple):
+    title: str
+    html: str  # Trusted html, not escaped
+    open: bool = False
+
+
+def accordion(panels, group="accordion", ctx=None):
+    """Cards that collapse, details elements sharing a name so the browser keeps
+    only one open at a time without any javascript"""
+    if ctx is None:
+        ctx = _ctx
+    group = html.escape(group)
+    result = ""
+    opened = False  # Only the first panel asking to be open can be
+    for panel in panels:
+        attr = ""
+        if panel.open and not opened:
+            attr = " open"
+            opened = True
+        result += f'<details class="card" name="{group}"{attr}>\n'
+        result += (
+            '  <summary class="card-header"><p class="card-header-title">'
+            f"{html.escape(panel.title)}</p></summary>\n"
+        )
+        result += f'  <div class="card-content">\n{panel.html}\n  </div>\n'
+        result += "</details>\n"
+    ctx.put(result)
@@ -0,0 +1,20 @@
+import html
+
+from .context import _ctx
+
+
+def list(items, ordered=False, escape=True, ctx=None):
+    if ctx is None:
+        ctx = _ctx
+    if not items:
+        return  # An empty list is always omitted rather than rendered empty
+    ctx.record("list", items=[str(item) for item in items], ordered=ordered)
+    tag = "ol" if ordered else "ul"
+    # Bulma resets list styling so wrap in content to get bullets and numbers back
+    result = f'<div class="content"><{tag}>\n'
+    for item in items:
+        if escape:
+            item = html.escape(str(item))
+        result += f"  <li>{item}</li>\n"
+    result += f"</{tag}></div>\n"
+    ctx.put(result)
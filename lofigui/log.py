@@ -0,0 +1,33 @@
+import datetime
+import html
+
+from .context import _ctx
+from .markdown import table_cells
+
+LOG_COLORS = {"info": "info", "warn": "warning", "error": "danger"}
+TIME_FORMAT = "%H:%M:%S"
+
+
+def log_line(level, message, when=None, ctx=None):
+    """Timestamped event line, also kept on the context for log_table"""
+    if ctx is None:
+        ctx = _ctx
+    if level not in LOG_COLORS:
+        level = "info"
+    if when is None:
+        when = datetime.datetime.now()
+    stamp = when.strftime(TIME_FORMAT)
+    message = str(message)
+    ctx.log_lines.append((stamp, level, message))
+    ctx.record("log", time=stamp, level=level, message=message)
+    ctx.put(
+        f'<p class="has-text-{LOG_COLORS[level]}"><code>{stamp}</code>'
+        f" {html.escape(message)}</p>\n"
+    )
+
+
+def log_table(ctx=None):
+    """All the lines logged so far as a table"""
+    if ctx is None:
+        ctx = _ctx
+    table_cells(ctx.log_lines, header=["Time", "Level", "Message"], ctx=ctx)
@@ -1,42 +1,379 @@
+import dataclasses
+import html as htmllib
+import re
+from typing import NamedTuple
+
 import markdown as mkdwn
 
-from .context import _ctx
+from .context import FORMAT_JSON, _ctx, _require_append
+from .sanitize import sanitize
+
+
+_MD_ALIGN = re.compile(r' style="text-align: (left|center|right);"')
+
+
+def _bulma_tables(md):
+    # Give markdown tables the same look as table(), including column alignment
+    md = md.replace("<table>", '<table class="table is-bordered is-striped">')
+    return _MD_ALIGN.sub(lambda m: f' class="{ALIGN_CLASSES[m.group(1)]}"', md)
+
+
+def _render_markdown(msg, ctx):
+    if ctx.markdown_renderer is not None:
+        return ctx.markdown_renderer(msg)
+    return _bulma_tables(mkdwn.markdown(msg, extensions=["tables"]))
+
+
+def set_markdown_renderer(renderer, ctx=None):
+    """Swap python-markdown for any function taking markdown text and returning
+    html, None goes back to the default"""
+    if ctx is None:
+        ctx = _ctx
+    ctx.markdown_renderer = renderer
 
 
 def markdown(msg="", ctx=None):
     if ctx is None:
         ctx = _ctx
-    md = mkdwn.markdown(msg)
-    ctx.queue.put_nowait(md)
+    ctx.record("markdown", text=msg)
+    md = _render_markdown(msg, ctx)
+    ctx.put(md)
+
+
+def markdown_with(msg="", tables=True, hard_breaks=False, ctx=None):
+    """markdown() with python-markdown's extensions picked, tables are on as they
+    are for markdown().  A renderer from set_markdown_renderer is used instead if
+    there is one, so the flags are then ignored"""
+    if ctx is None:
+        ctx = _ctx
+    ctx.record("markdown", text=msg, tables=tables, hard_breaks=hard_breaks)
+    if ctx.markdown_renderer is not None:
+        ctx.put(ctx.markdown_renderer(msg))
+        return
+    extensions = []
+    if tables:
+        extensions.append("tables")
+    if hard_breaks:
+        extensions.append("nl2br")
+    ctx.put(_bulma_tables(mkdwn.markdown(msg, extensions=extensions)))
+
+
+def markdown_safe(msg="", ctx=None):
+    """Markdown from untrusted input eg user notes, the html is sanitized"""
+    if ctx is None:
+        ctx = _ctx
+    ctx.record("markdown", text=msg, safe=True)
+    ctx.put(sanitize(_render_markdown(msg, ctx)))
+
+
+def escape(s):
+    """Escape text to go between tags when building html by hand"""
+    return htmllib.escape(str(s), quote=False)
+
+
+def escape_attr(s):
+    """Escape a value to go inside a quoted attribute, also escapes quotes"""
+    return htmllib.escape(str(s), quote=True)
 
 
 def html(msg="", ctx=None):
+    """Raw html for trusted input only, msg is not escaped so use escape() and
+    escape_attr() on any values put in it"""
     if ctx is None:
         ctx = _ctx
-    ctx.queue.put_nowait(msg)
+    ctx.record("html", html=msg)
+    ctx.put(msg)
+
 
+def htmlf(format, *args, ctx=None):
+    """html() with % style formatting, the format is raw html but every argument
+    other than a number is escaped eg htmlf('<a href="%s">%s</a>', url, name)"""
+    # bool is an int so is left alone too, numbers keep working with %d and %f.
+    # Quotes are escaped as well so an argument can't break out of an attribute.
+    args = tuple(a if isinstance(a, (int, float)) else escape_attr(a) for a in args)
+    html(format % args, ctx=ctx)
 
-def table(table, header=[], ctx=None):
+
+def html_safe(msg="", ctx=None):
+    """Semi-trusted html, passed through sanitize() first.  This parses the html
+    so is much slower than html() and is best kept for user supplied content"""
     if ctx is None:
         ctx = _ctx
+    msg = sanitize(msg)
+    ctx.record("html", html=msg)
+    ctx.put(msg)
+
+
+# Bulma's class for each alignment, note centre is has-text-centered
+ALIGN_CLASSES = {
+    "left": "has-text-left",
+    "center": "has-text-centered",
+    "right": "has-text-right",
+}
+
+
+def _align_class(align, i):
+    # Columns beyond the end of align, or with an unknown value, stay left aligned
+    if align and i < len(align) and align[i] in ALIGN_CLASSES:
+        return f' class="{ALIGN_CLASSES[align[i]]}"'
+    return ""
+
+
+class Cell(NamedTuple):
+    """A table cell, escaped unless raw is set for trusted markup"""
+
+    value: object
+    raw: bool = False
+
+
+def _cell_value(cell):
+    return str(cell.value) if isinstance(cell, Cell) else str(cell)
+
+
+def _cell_html(cell):
+    # Plain values are treated as escaped cells
+    if not isinstance(cell, Cell):
+        cell = Cell(cell)
+    return str(cell.value) if cell.raw else htmllib.escape(str(cell.value))
+
+
+def _format_cell(cell, fn):
+    if isinstance(cell, Cell):
+        return cell._replace(value=fn(str(cell.value)))
+    return fn(str(cell))
+
+
+def _apply_formats(data, formats):
+    """formats maps a column index to a function applied to each cell before escaping"""
+    if not formats:
+        return data
+    return [
+        [
+            _format_cell(field, formats[i]) if i in formats else field
+            for i, field in enumerate(row)
+        ]
+        for row in data
+    ]
+
+
+def table_cells(
+    data,
+    header=[],
+    ctx=None,
+    align=None,
+    formats=None,
+    empty_message="",
+    caption="",
+    footer=None,
+):
+    if ctx is None:
+        ctx = _ctx
+    data = _apply_formats(data, formats)
+    ctx.record(
+        "table",
+        header=[_cell_value(field) for field in header],
+        rows=[[_cell_value(field) for field in row] for row in data],
+    )
+    result = _table_start(header, align, caption)
+    if data:
+        result += "  <tbody>\n"
+        for row in data:
+            result += _table_row(row, header, align)
+        result += "  </tbody>\n"
+    elif empty_message:
+        # A single row across the whole table rather than an empty body
+        span = max(len(header), 1)
+        result += "  <tbody>\n    <tr>\n"
+        result += f'      <td colspan="{span}">{htmllib.escape(empty_message)}</td>\n'
+        result += "    </tr>\n  </tbody>\n"
+    if footer:
+        # eg a totals row
+        result += "  <tfoot><tr>\n"
+        for i, field in enumerate(footer):
+            result += f"    <th{_align_class(align, i)}>{_cell_html(field)}</th>\n"
+        result += "  </tr></tfoot>\n"
+    result += "</table>\n"
+    ctx.put(result)
+
+
+def _table_start(header, align, caption=""):
     result = '<table class="table is-bordered is-striped">\n'
+    if caption:
+        result += f"  <caption>{htmllib.escape(caption)}</caption>\n"
     if header:
         result += "  <thead><tr>\n"
-        for field in header:
-            result += f"    <th>{field}</th>\n"
+        for i, field in enumerate(header):
+            result += f"    <th{_align_class(align, i)}>{_cell_html(field)}</th>\n"
         result += "  </tr></thead>\n"
-    if table:
-        result += "  <tbody>\n"
-        for row in table:
-            # Make last field expand eg use one field to go alway across
-            extend_last_field = header and len(header) > len(row)
-            result += "    <tr>\n"
-            for i, field in enumerate(row):
-                if extend_last_field and i == len(row) - 1:
-                    result += f'      <td colspan="{len(header)-i}">{field}</td>\n'
-                else:
-                    result += f"      <td>{field}</td>\n"
-            result += "    </tr>\n"
-        result += "  </tbody>\n"
-    result += "</table>\n"
-    ctx.queue.put_nowait(result)
+    return result
+
+
+def _table_row(row, header, align):
+    # Make last field expand eg use one field to go alway across
+    extend_last_field = header and len(header) > len(row)
+    result = "    <tr>\n"
+    for i, field in enumerate(row):
+        cls = _align_class(align, i)
+        value = _cell_html(field)
+        if extend_last_field and i == len(row) - 1:
+            result += f'      <td colspan="{len(header)-i}"{cls}>{value}</td>\n'
+        else:
+            result += f"      <td{cls}>{value}</td>\n"
+    result += "    </tr>\n"
+    return result
+
+
+def table(
+    table,
+    header=[],
+    ctx=None,
+    align=None,
+    formats=None,
+    empty_message="",
+    caption="",
+    footer=None,
+):
+    # table has always passed its contents through as html
+    table_cells(
+        [[Cell(field, raw=True) for field in row] for row in table],
+        header=[Cell(field, raw=True) for field in header],
+        ctx=ctx,
+        align=align,
+        formats=formats,
+        empty_message=empty_message,
+        caption=caption,
+        footer=[Cell(field, raw=True) for field in footer or []],
+    )
+
+
+@dataclasses.dataclass
+class TableConfig:
+    """All the table options in one place, see table_with"""
+
+    header: list = dataclasses.field(default_factory=list)
+    escape: bool = True
+    align: list = None
+    caption: str = ""
+    footer: list = None
+    formats: dict = None
+    empty_message: str = ""
+
+
+def table_with(data, cfg, ctx=None):
+    """Same output as table_cells (escape=True) or table (escape=False) given the
+    equivalent keyword arguments"""
+    render = table_cells if cfg.escape else table
+    render(
+        data,
+        header=cfg.header,
+        ctx=ctx,
+        align=cfg.align,
+        formats=cfg.formats,
+        empty_message=cfg.empty_message,
+        caption=cfg.caption,
+        footer=cfg.footer,
+    )
+
+
+class TableWriter:
+    """Streams a table a row at a time, the output matches table() for the same data
+
+    with lg.table_writer(["Name", "Value"]) as tw:
+        for name, value in results():
+            tw.row([name, value])
+    """
+
+    def __init__(self, header=[], ctx=None, align=None):
+        if ctx is None:
+            ctx = _ctx
+        _require_append(ctx, "table_writer")
+        self.ctx = ctx
+        self.header = [Cell(field, raw=True) for field in header]
+        self.align = align
+        self.rows = 0
+        # Only kept in json format, otherwise rows are not held in memory
+        self.recorded = []
+        ctx.put(_table_start(self.header, align))
+
+    def row(self, row):
+        if self.rows == 0:
+            self.ctx.put("  <tbody>\n")
+        self.rows += 1
+        if self.ctx.format == FORMAT_JSON:
+            self.recorded.append([str(field) for field in row])
+        row = [Cell(field, raw=True) for field in row]
+        self.ctx.put(_table_row(row, self.header, self.align))
+
+    def close(self):
+        if self.rows:
+            self.ctx.put("  </tbody>\n")
+        self.ctx.put("</table>\n")
+        # Recorded once at the end, the same element table() records
+        self.ctx.record(
+            "table",
+            header=[_cell_value(field) for field in self.header],
+            rows=self.recorded,
+        )
+
+    def __enter__(self):
+        return self
+
+    def __exit__(self, *exc):
+        self.close()
+
+
+def table_writer(header=[], ctx=None, align=None):
+    return TableWriter(header, ctx=ctx, align=align)
+
+
+def table_objects(rows, ctx=None):
+    """Table from a list of dataclass instances.
+
+    Headers come from the field names unless the field has lofigui metadata eg
+    field(metadata={"lofigui": "Header"}).  Fields starting with _ are skipped.
+    Headers and values are escaped."""
+    if not isinstance(rows, (list, tuple)):
+        raise TypeError(f"table_objects needs a list of dataclasses not {type(rows)}")
+    if not rows:
+        table_cells([], ctx=ctx)
+        return
+    first = rows[0]
+    if not dataclasses.is_dataclass(first) or isinstance(first, type):
+        raise TypeError(f"table_objects rows must be dataclasses not {type(first)}")
+    fields = [f for f in dataclasses.fields(first) if not f.name.startswith("_")]
+    header = [f.metadata.get("lofigui", f.name) for f in fields]
+    data = []
+    for row in rows:
+        if type(row) is not type(first):
+            raise TypeError(
+                f"table_objects rows must all be {type(first)} not {type(row)}"
+            )
+        data.append([str(getattr(row, f.name)) for f in fields])
+    table_cells(data, header=header, ctx=ctx)  # Data not markup so escaped
+
+
+def code_block(code, language="", ctx=None):
+    if ctx is None:
+        ctx = _ctx
+    ctx.record("code", code=code, language=language)
+    cls = f' class="language-{htmllib.escape(language)}"' if language else ""
+    ctx.put(f"<pre><code{cls}>{htmllib.escape(code)}</code></pre>\n")
+
+
+def code_block_highlighted(code, language, ctx=None):
+    """Code block with inline styled syntax highlighting, needs the pygments extra"""
+    if ctx is None:
+        ctx = _ctx
+    # Imported here so that plain lofigui use doesn't need pygments
+    from pygments import highlight
+    from pygments.formatters import HtmlFormatter
+    from pygments.lexers import get_lexer_by_name
+    from pygments.util import ClassNotFound
+
+    try:
+        lexer = get_lexer_by_name(language)
+    except ClassNotFound:
+        code_block(code, language, ctx=ctx)  # Unknown language so leave it plain
+        return
+    ctx.record("code", code=code, language=language)
+    ctx.put(highlight(code, lexer, HtmlFormatter(noclasses=True)))
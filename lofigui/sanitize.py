@@ -0,0 +1,111 @@
+import html
+from html.parser import HTMLParser
+
+# Roughly the set of tags user generated content needs, much like bluemonday's UGC
+# policy
+# fmt: off
+ALLOWED_TAGS = {
+    "a", "abbr", "b", "blockquote", "br", "code", "dd", "del", "div", "dl", "dt",
+    "em", "h1", "h2", "h3", "h4", "h5", "h6", "hr", "i", "img", "li", "ol", "p",
+    "pre", "s", "span", "strong", "sub", "sup", "table", "tbody", "td", "tfoot",
+    "th", "thead", "tr", "u", "ul",
+}
+# fmt: on
+ALLOWED_ATTRS = {
+    "a": {"href", "title"},
+    "img": {"src", "alt", "title", "width", "height"},
+    "table": {"class"},
+    "td": {"colspan", "rowspan", "align", "class"},
+    "th": {"colspan", "rowspan", "align", "class"},
+    "code": {"class"},  # For language-x classes
+}
+# Tags whose class attribute is cut down to these, so markdown tables keep their
+# Bulma look without letting user content restyle the page
+_ALIGN = {"has-text-left", "has-text-centered", "has-text-right"}
+SAFE_CLASSES = {
+    "table": {"table", "is-bordered", "is-striped"},
+    "td": _ALIGN,
+    "th": _ALIGN,
+}
+URL_ATTRS = {"href", "src"}
+SAFE_SCHEMES = ("http:", "https:", "mailto:")
+DROP_CONTENT = {"script", "style", "iframe", "object", "embed"}
+VOID_TAGS = {"br", "hr", "img"}
+
+
+def _safe_url(url):
+    url = url.strip()
+    if ":" not in url.split("/", 1)[0]:
+        return True  # Relative url
+    return url.lower().startswith(SAFE_SCHEMES)
+
+
+class _Sanitizer(HTMLParser):
+    def __init__(self):
+        super().__init__(convert_charrefs=True)
+        self.result = []
+        self.dropping = 0  # Depth inside tags whose content is removed
+        self.open_tags = []  # Allowed tags opened so far, so closers must match
+
+    def _tag(self, tag, attrs):
+        allowed = ALLOWED_ATTRS.get(tag, set())
+        out = tag
+        for name, value in attrs:
+            if name not in allowed or value is None:
+                continue
+            if name in URL_ATTRS and not _safe_url(value):
+                continue
+            if name == "class" and tag in SAFE_CLASSES:
+                value = " ".join(c for c in value.split() if c in SAFE_CLASSES[tag])
+                if not value:
+                    continue
+            out += f' {name}="{html.escape(value)}"'
+        return f"<{out}>"
+
+    def handle_starttag(self, tag, attrs):
+        if tag in DROP_CONTENT:
+            self.dropping += 1
+            return
+        if self.dropping or tag not in ALLOWED_TAGS:
+            return
+        self.result.append(self._tag(tag, attrs))
+        if tag not in VOID_TAGS:
+            self.open_tags.append(tag)
+
+    def handle_startendtag(self, tag, attrs):
+        # A self closing drop tag such as <script/> has no content to drop
+        if self.dropping or tag not in ALLOWED_TAGS:
+            return
+        self.result.append(self._tag(tag, attrs))
+        if tag not in VOID_TAGS:
+            self.result.append(f"</{tag}>")
+
+    def handle_endtag(self, tag):
+        if tag in DROP_CONTENT:
+            self.dropping = max(self.dropping - 1, 0)
+            return
+        if self.dropping or tag not in self.open_tags:
+            return  # Never opened here so it would close the page's own html
+        while self.open_tags:
+            opened = self.open_tags.pop()
+            self.result.append(f"</{opened}>")
+            if opened == tag:
+                break
+
+    def handle_data(self, data):
+        if not self.dropping:
+            self.result.append(html.escape(data, quote=False))
+
+    def close(self):
+        super().close()
+        while self.open_tags:
+            self.result.append(f"</{self.open_tags.pop()}>")
+
+
+def sanitize(msg):
+    """Strip html down to an allow list of tags and attributes, removing scripts,
+    event handlers and javascript: urls"""
+    parser = _Sanitizer()
+    parser.feed(msg)
+    parser.close()
+    return "".join(parser.result)
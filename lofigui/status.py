@@ -0,0 +1,39 @@
+import enum
+import html
+
+from .context import _ctx
+
+
+class StatusLevel(enum.Enum):
+    OK = "ok"
+    WARNING = "warning"
+    DANGER = "danger"
+
+
+# One mapping for both html (Bulma classes) and svg (Bulma's hex colours)
+STATUS_CLASSES = {
+    StatusLevel.OK: "is-success",
+    StatusLevel.WARNING: "is-warning",
+    StatusLevel.DANGER: "is-danger",
+}
+STATUS_HEX = {
+    StatusLevel.OK: "#48c78e",
+    StatusLevel.WARNING: "#ffe08a",
+    StatusLevel.DANGER: "#f14668",
+}
+
+
+def status_class(level):
+    return STATUS_CLASSES[level]
+
+
+def status_color(level):
+    return STATUS_HEX[level]
+
+
+def status_tag(level, text, ctx=None):
+    if ctx is None:
+        ctx = _ctx
+    ctx.put(
+        f'<span class="tag {status_class(level)}">{html.escape(str(text))}</span>\n'
+    )
@@ -0,0 +1,267 @@
+import pytest
+
+import lofigui as lg
+
+
+def test_heading_class():
+    ctx = lg.PrintContext()
+    lg.heading(2, "Tank", ctx=ctx)
+    assert lg.buffer(ctx) == '<h2 class="title is-2">Tank</h2>\n'
+
+
+def test_heading_clamps_level():
+    ctx = lg.PrintContext()
+    lg.heading(0, "low", ctx=ctx)
+    lg.heading(9, "high", ctx=ctx)
+    result = lg.buffer(ctx)
+    assert '<h1 class="title is-1">low</h1>' in result
+    assert '<h6 class="title is-6">high</h6>' in result
+
+
+def test_heading_escapes_but_heading_html_does_not():
+    ctx = lg.PrintContext()
+    lg.heading(3, "<b>x</b>", ctx=ctx)
+    lg.heading_html(3, "<b>x</b>", ctx=ctx)
+    result = lg.buffer(ctx)
+    assert "&lt;b&gt;x&lt;/b&gt;" in result
+    assert '<h3 class="title is-3"><b>x</b></h3>' in result
+
+
+def test_notification_kinds():
+    ctx = lg.PrintContext()
+    lg.notification("success", "Saved", ctx=ctx)
+    lg.notification("", "Plain", ctx=ctx)
+    lg.notification("bogus", "Odd", ctx=ctx)
+    result = lg.buffer(ctx)
+    assert '<div class="notification is-success">Saved</div>' in result
+    assert '<div class="notification">Plain</div>' in result
+    assert '<div class="notification">Odd</div>' in result
+
+
+def test_notification_escapes():
+    ctx = lg.PrintContext()
+    lg.notification("danger", "<script>", ctx=ctx)
+    assert "&lt;script&gt;" in lg.buffer(ctx)
+
+
+def test_button_plain():
+    ctx = lg.PrintContext()
+    lg.button("Go", cls="is-info", ctx=ctx)
+    assert lg.buffer(ctx) == '<button class="button is-info">Go</button>\n'
+
+
+def test_button_href():
+    ctx = lg.PrintContext()
+    lg.button("Home", href="/?a=1&b=2", ctx=ctx)
+    assert lg.buffer(ctx) == '<a class="button" href="/?a=1&amp;b=2">Home</a>\n'
+
+
+def test_button_action():
+    ctx = lg.PrintContext()
+    lg.button("Delete", cls="is-danger", action="/delete/1", ctx=ctx)
+    result = lg.buffer(ctx)
+    assert '<form action="/delete/1" method="post">' in result
+    assert '<button class="button is-danger" type="submit">Delete</button>' in result
+
+
+def test_button_href_and_action():
+    ctx = lg.PrintContext()
+    with pytest.raises(ValueError):
+        lg.button("x", action="/a", href="/b", ctx=ctx)
+
+
+def test_button_label_escaped():
+    ctx = lg.PrintContext()
+    lg.button("<b>", ctx=ctx)
+    assert "&lt;b&gt;" in lg.buffer(ctx)
+
+
+def test_key_values_order_and_escaping():
+    ctx = lg.PrintContext()
+    lg.key_values([("Level", "50%"), ("Pump", "<on>")], title="Diagnostics", ctx=ctx)
+    result = lg.buffer(ctx)
+    assert '<h4 class="title is-4">Diagnostics</h4>' in result
+    assert result.index("Level") < result.index("Pump")
+    assert "<td>&lt;on&gt;</td>" in result
+
+
+TABS = [
+    lg.TabItem("schematic", "Schematic", "/"),
+    lg.TabItem("diag", "<Diagnostics>", "/diag"),
+]
+
+
+def test_tabs_active():
+    ctx = lg.PrintContext()
+    lg.tabs(TABS, "diag", ctx=ctx)
+    result = lg.buffer(ctx)
+    assert result.count("is-active") == 1
+    assert (
+        '<li class="is-active"><a href="/diag">&lt;Diagnostics&gt;</a></li>' in result
+    )
+
+
+def test_tabs_no_match():
+    ctx = lg.PrintContext()
+    lg.tabs(TABS, "missing", ctx=ctx)
+    assert "is-active" not in lg.buffer(ctx)
+
+
+def test_card_body_only():
+    result = lg.Card().body_html("<p>hi</p>").render()
+    assert '<div class="card-content">\n<p>hi</p>' in result
+    assert "card-header" not in result
+    assert "card-footer" not in result
+
+
+def test_card_full():
+    ctx = lg.PrintContext()
+    card = lg.Card().header("<Tank>").body_html("<b>50%</b>").footer_item("Edit", "/e")
+    card.write(ctx)
+    result = lg.buffer(ctx)
+    assert '<p class="card-header-title">&lt;Tank&gt;</p>' in result
+    assert "<b>50%</b>" in result
+    assert '<a href="/e" class="card-footer-item">Edit</a>' in result
+
+
+def test_image_size_and_caption():
+    ctx = lg.PrintContext()
+    lg.image("/chart.svg", alt="Level", size="128x128", caption="Today", ctx=ctx)
+    result = lg.buffer(ctx)
+    assert '<figure class="image is-128x128">' in result
+    assert '<img src="/chart.svg" alt="Level">' in result
+    assert "<figcaption>Today</figcaption>" in result
+
+
+def test_image_unknown_size_and_quote():
+    ctx = lg.PrintContext()
+    lg.image('/a"onerror="x', size="7x7", ctx=ctx)
+    result = lg.buffer(ctx)
+    assert '<figure class="image">' in result
+    assert 'src="/a&quot;onerror=&quot;x"' in result
+    assert "figcaption" not in result
+
+
+def test_tag_colors():
+    ctx = lg.PrintContext()
+    lg.tag("on", "success", ctx=ctx)
+    lg.tag("off", "is-danger", ctx=ctx)
+    lg.tag("odd", "mauve", ctx=ctx)
+    lg.tag("dim", "info", light=True, ctx=ctx)
+    result = lg.buffer(ctx)
+    assert '<span class="tag is-success">on</span>' in result
+    assert '<span class="tag is-danger">off</span>' in result
+    assert '<span class="tag is-light">odd</span>' in result
+    assert '<span class="tag is-info is-light">dim</span>' in result
+
+
+def test_tags_group():
+    ctx = lg.PrintContext()
+    lg.tags([("pump", "success"), ("valve", "warning")], ctx=ctx)
+    assert lg.buffer(ctx) == (
+        '<div class="tags">\n'
+        '  <span class="tag is-success">pump</span>\n'
+        '  <span class="tag is-warning">valve</span>\n'
+        "</div>\n"
+    )
+
+
+def test_pagination_ellipsis():
+    ctx = lg.PrintContext()
+    lg.pagination(5, 10, "/notes", ctx=ctx)
+    result = lg.buffer(ctx)
+    assert result.count("pagination-ellipsis") == 2
+    assert 'href="/notes?page=1"' in result
+    assert 'aria-current="page">5<' in result
+    assert 'href="/notes?page=10"' in result
+    assert "page=3" not in result
+
+
+def test_pagination_boundaries():
+    ctx = lg.PrintContext()
+    lg.pagination(1, 3, "/notes?sort=a", ctx=ctx)
+    result = lg.buffer(ctx)
+    assert '<a class="pagination-previous is-disabled">Previous</a>' in result
+    assert 'href="/notes?sort=a&amp;page=2">Next</a>' in result
+    assert "pagination-ellipsis" not in result
+    ctx = lg.PrintContext()
+    lg.pagination(99, 3, "/notes", ctx=ctx)
+    result = lg.buffer(ctx)
+    assert '<a class="pagination-next is-disabled">Next</a>' in result
+    assert 'aria-current="page">3<' in result
+
+
+def test_pagination_single_page():
+    ctx = lg.PrintContext()
+    lg.pagination(1, 1, "/notes", ctx=ctx)
+    assert lg.buffer(ctx) == ""
+
+
+def test_stat_escapes():
+    ctx = lg.PrintContext()
+    lg.stat("<Level>", "<50%>", color="has-text-success", ctx=ctx)
+    result = lg.buffer(ctx)
+    assert '<p class="title is-2 has-text-success">&lt;50%&gt;</p>' in result
+    assert '<p class="heading">&lt;Level&gt;</p>' in result
+    assert "stat-trend" not in result
+
+
+def test_stat_trend():
+    ctx = lg.PrintContext()
+    lg.stat("Level", 50, trend="up", ctx=ctx)
+    lg.stat("Flow", 3, trend="sideways", ctx=ctx)
+    result = lg.buffer(ctx)
+    assert result.count("stat-trend-up") == 1
+    assert "sideways" not in result
+
+
+def test_spinner_only_while_running():
+    def render(running, ctx):
+        if running:
+            lg.spinner("Filling", ctx=ctx)
+        lg.html("<p>tank</p>", ctx=ctx)
+
+    ctx = lg.PrintContext()
+    render(True, ctx)
+    result = lg.buffer(ctx)
+    assert '<span class="button is-loading is-static is-white">Filling</span>' in result
+    ctx = lg.PrintContext()
+    render(False, ctx)
+    assert "is-loading" not in lg.buffer(ctx)
+
+
+def test_breadcrumb():
+    ctx = lg.PrintContext()
+    lg.breadcrumb(
+        [lg.BreadcrumbItem("Home", "/"), lg.BreadcrumbItem("<Diag>", "/diag")], ctx=ctx
+    )
+    result = lg.buffer(ctx)
+    assert '<li><a href="/">Home</a></li>' in result
+    assert '<li class="is-active"><a aria-current="page">&lt;Diag&gt;</a>' in result
+    assert 'href="/diag"' not in result
+
+
+def test_breadcrumb_empty():
+    ctx = lg.PrintContext()
+    lg.breadcrumb([], ctx=ctx)
+    assert lg.buffer(ctx) == ""
+
+
+def test_error_is_danger_notification():
+    ctx = lg.PrintContext()
+    lg.error(ValueError("bad <id>"), ctx=ctx)
+    result = lg.buffer(ctx)
+    assert result == '<div class="notification is-danger">bad &lt;id&gt;</div>\n'
+
+
+def test_heading_accepts_any_value():
+    ctx = lg.PrintContext()
+    lg.heading(2, 42, ctx=ctx)
+    assert lg.buffer(ctx) == '<h2 class="title is-2">42</h2>\n'
+
+
+def test_notification_accepts_any_value():
+    ctx = lg.PrintContext()
+    lg.notification("danger", ValueError("bad <id>"), ctx=ctx)
+    result = lg.buffer(ctx)
+    assert result == '<div class="notification is-danger">bad &lt;id&gt;</div>\n'
@@ -0,0 +1,68 @@
+import lofigui as lg
+
+
+def test_line_chart():
+    ctx = lg.PrintContext()
+    lg.line_chart([1, 3, 2], title="Level", ctx=ctx)
+    result = lg.buffer(ctx)
+    assert result.startswith("<svg")
+    assert 'viewBox="0 0 600 300"' in result
+    assert "width:100%" in result
+    assert result.count("<polyline") == 1
+    assert ">Level</text>" in result
+
+
+def test_bar_chart():
+    ctx = lg.PrintContext()
+    lg.bar_chart([1, 2, 3], labels=["a", "b", "c"], color="red", ctx=ctx)
+    result = lg.buffer(ctx)
+    assert result.count("<rect") == 3
+    assert ">b</text>" in result
+    assert 'fill="red"' in result
+
+
+def test_empty_series():
+    ctx = lg.PrintContext()
+    lg.line_chart([], ctx=ctx)
+    lg.bar_chart([], ctx=ctx)
+    result = lg.buffer(ctx)
+    assert result.count("<svg") == 2
+    assert "<polyline" not in result
+    assert "<rect" not in result
+
+
+def test_flat_series():
+    ctx = lg.PrintContext()
+    lg.line_chart([0, 0], ctx=ctx)
+    assert "nan" not in lg.buffer(ctx)
+
+
+def test_time_series_evicts_oldest():
+    ts = lg.TimeSeries(max_points=3)
+    for v in range(5):
+        ts.add(v)
+    assert ts.values() == [2, 3, 4]
+
+
+def test_time_series_scales_to_data_range():
+    ts = lg.TimeSeries()
+    for v in (100, 110, 105):
+        ts.add(v)
+    ctx = lg.PrintContext()
+    ts.render_svg(width=200, height=40, ctx=ctx)
+    assert 'points="0.0,40.0 100.0,0.0 200.0,20.0"' in lg.buffer(ctx)
+
+
+def test_svg_wrapper():
+    ctx = lg.PrintContext()
+    lg.svg('<circle r="5"/>', "0 0 740 400", max_width=740, ctx=ctx)
+    result = lg.buffer(ctx)
+    assert 'viewBox="0 0 740 400"' in result
+    assert 'style="max-width:740px;width:100%;height:auto"' in result
+    assert '<circle r="5"/>\n</svg>' in result
+
+
+def test_svg_wrapper_without_max_width():
+    ctx = lg.PrintContext()
+    lg.svg("", "0 0 10 10", ctx=ctx)
+    assert 'style="width:100%;height:auto"' in lg.buffer(ctx)
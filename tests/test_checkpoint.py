@@ -0,0 +1,46 @@
+import lofigui as lg
+
+
+def test_rollback_keeps_output_before_checkpoint():
+    ctx = lg.PrintContext()
+    lg.html("<p>kept</p>", ctx=ctx)
+    cp = lg.checkpoint(ctx)
+    lg.html("<p>dropped</p>", ctx=ctx)
+    lg.rollback(cp, ctx)
+    assert lg.buffer(ctx) == "<p>kept</p>"
+
+
+def test_rollback_discards_json_elements():
+    ctx = lg.PrintContext()
+    lg.set_format(lg.FORMAT_JSON, ctx)
+    lg.html("<p>kept</p>", ctx=ctx)
+    cp = lg.checkpoint(ctx)
+    lg.html("<p>dropped</p>", ctx=ctx)
+    lg.rollback(cp, ctx)
+    assert ctx.elements == [{"type": "html", "html": "<p>kept</p>"}]
+
+
+def test_capture_returns_output_and_restores():
+    ctx = lg.PrintContext()
+    lg.set_format(lg.FORMAT_JSON, ctx)
+    lg.html("<p>before</p>", ctx=ctx)
+    result = lg.capture(lambda: lg.html("<p>inner</p>", ctx=ctx), ctx=ctx)
+    assert result == "<p>inner</p>"
+    assert lg.buffer(ctx) == "<p>before</p>"
+    assert len(ctx.elements) == 1
+
+
+def test_capture_leaves_prior_buffer_intact():
+    ctx = lg.PrintContext()
+    lg.html("<main>", ctx=ctx)
+    fragment = lg.capture(lambda: lg.html("<div>tank</div>", ctx=ctx), ctx=ctx)
+    lg.html("</main>", ctx=ctx)
+    assert fragment == "<div>tank</div>"
+    assert lg.buffer(ctx) == "<main></main>"
+
+
+def test_capture_nothing():
+    ctx = lg.PrintContext()
+    lg.html("<p>x</p>", ctx=ctx)
+    assert lg.capture(lambda: None, ctx=ctx) == ""
+    assert lg.buffer(ctx) == "<p>x</p>"
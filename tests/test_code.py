@@ -0,0 +1,34 @@
+import pytest
+
+import lofigui as lg
+
+
+def test_code_block_escapes():
+    ctx = lg.PrintContext()
+    lg.code_block("if a < b {}", "go", ctx=ctx)
+    result = lg.buffer(ctx)
+    assert '<pre><code class="language-go">' in result
+    assert "a &lt; b" in result
+
+
+def test_code_block_without_language():
+    ctx = lg.PrintContext()
+    lg.code_block("x", ctx=ctx)
+    assert lg.buffer(ctx) == "<pre><code>x</code></pre>\n"
+
+
+def test_highlighted():
+    pytest.importorskip("pygments")
+    ctx = lg.PrintContext()
+    lg.code_block_highlighted("if a < b:\n    pass\n", "python", ctx=ctx)
+    result = lg.buffer(ctx)
+    assert "style=" in result
+    assert "&lt;" in result
+
+
+def test_highlighted_unknown_language_falls_back():
+    pytest.importorskip("pygments")
+    ctx = lg.PrintContext()
+    lg.code_block_highlighted("a < b", "nosuchlang", ctx=ctx)
+    result = lg.buffer(ctx)
+    assert '<pre><code class="language-nosuchlang">a &lt; b</code></pre>' in result
@@ -0,0 +1,101 @@
+import io
+
+import lofigui as lg
+
+
+def render(ctx):
+    lg.html("<p>tank 50%</p>", ctx=ctx)
+
+
+def test_identical_renders_unchanged():
+    ctx = lg.PrintContext()
+    render(ctx)
+    prev = lg.snapshot(ctx)
+    lg.reset(ctx)
+    render(ctx)
+    changed, current = lg.diff(prev, ctx)
+    assert not changed
+    assert current == prev
+
+
+def test_changed_render():
+    ctx = lg.PrintContext()
+    render(ctx)
+    prev = lg.snapshot(ctx)
+    lg.html("<p>pump on</p>", ctx=ctx)
+    changed, _ = lg.diff(prev, ctx)
+    assert changed
+
+
+def test_region_wraps_output():
+    ctx = lg.PrintContext()
+    lg.region("tank", lambda: render(ctx), ctx=ctx)
+    assert lg.buffer(ctx) == '<div id="lofi-tank">\n<p>tank 50%</p></div>\n'
+
+
+def test_render_once_does_not_accumulate():
+    ctx = lg.PrintContext()
+    first = lg.render_once(lambda: render(ctx), ctx=ctx)
+    second = lg.render_once(lambda: render(ctx), ctx=ctx)
+    assert first == second == "<p>tank 50%</p>"
+    assert lg.buffer(ctx) == ""
+
+
+def test_render_once_drops_earlier_output():
+    ctx = lg.PrintContext()
+    lg.html("<p>stale</p>", ctx=ctx)
+    assert lg.render_once(lambda: render(ctx), ctx=ctx) == "<p>tank 50%</p>"
+
+
+def test_named_regions_are_isolated():
+    ctx = lg.PrintContext()
+    lg.html("<p>page</p>", ctx=ctx)
+    lg.html("<svg/>", ctx=lg.named("schematic", ctx))
+    lg.html("<table/>", ctx=lg.named("diagnostics", ctx))
+    assert lg.render_named("schematic", ctx) == "<svg/>"
+    assert lg.render_named("diagnostics", ctx) == "<table/>"
+    assert lg.buffer(ctx) == "<p>page</p>"
+    assert lg.named("schematic", ctx) is lg.named("schematic", ctx)
+
+
+def test_is_empty_and_length():
+    ctx = lg.PrintContext()
+    assert lg.is_empty(ctx)
+    lg.print("hi", ctx=ctx)
+    assert not lg.is_empty(ctx)
+    assert lg.length(ctx) == len("<p>hi</p>\n")
+    lg.reset(ctx)
+    assert lg.is_empty(ctx)
+    assert lg.length(ctx) == 0
+
+
+def test_write_to_matches_buffer():
+    ctx = lg.PrintContext()
+    render(ctx)
+    f = io.StringIO()
+    assert lg.write_to(f, ctx) == len(lg.buffer(ctx))
+    assert f.getvalue() == lg.buffer(ctx)
+
+
+def test_reset_clears_named_regions():
+    ctx = lg.PrintContext()
+    fragment = lg.named("diagnostics", ctx)
+    lg.html("<p>x</p>", ctx=fragment)
+    lg.reset(ctx)
+    lg.html("<p>y</p>", ctx=fragment)
+    assert lg.render_named("diagnostics", ctx) == "<p>y</p>"
+    lg.html("<p>z</p>", ctx=fragment)
+    lg.render_once(lambda: None, ctx=ctx)
+    assert lg.render_named("diagnostics", ctx) == ""
+
+
+def test_named_region_inherits_settings():
+    ctx = lg.PrintContext()
+    lg.set_format(lg.FORMAT_JSON, ctx)
+    lg.set_mode(lg.MODE_PREPEND, ctx)
+    lg.set_markdown_renderer(str.upper, ctx=ctx)
+    fragment = lg.named("log", ctx)
+    lg.markdown("a", ctx=fragment)
+    lg.markdown("b", ctx=fragment)
+    assert lg.buffer(fragment) == "BA"
+    assert len(fragment.elements) == 2
@@ -0,0 +1,23 @@
+import lofigui as lg
+from lofigui import el
+
+
+def test_attribute_quotes_escaped():
+    assert el("div", {"title": 'say "hi"'}) == '<div title="say &quot;hi&quot;"></div>'
+
+
+def test_children_in_order():
+    result = el("ul", {"class": "list"}, el("li", None, "a"), el("li", None, "<b>"))
+    assert result == '<ul class="list"><li>a</li><li>&lt;b&gt;</li></ul>'
+
+
+def test_bool_attributes_and_void():
+    assert el("input", {"required": True, "disabled": False, "value": 3}) == (
+        '<input required value="3">'
+    )
+
+
+def test_used_with_html():
+    ctx = lg.PrintContext()
+    lg.html(el("p", None, "Level ", 50), ctx=ctx)
+    assert lg.buffer(ctx) == "<p>Level 50</p>"
@@ -0,0 +1,21 @@
+import io
+
+import lofigui as lg
+
+
+def test_csv_quoting():
+    f = io.StringIO()
+    rows = [["a, b", 1], ['say "hi"', 2], ["two\nlines", 3]]
+    lg.write_csv(f, ["Note", "Count"], rows)
+    assert f.getvalue() == (
+        "Note,Count\r\n"
+        '"a, b",1\r\n'
+        '"say ""hi""",2\r\n'
+        '"two\nlines",3\r\n'
+    )
+
+
+def test_csv_headers():
+    headers = lg.csv_headers('no"tes\r\n.csv')
+    assert headers["Content-Type"].startswith("text/csv")
+    assert headers["Content-Disposition"] == 'attachment; filename="notes.csv"'
@@ -0,0 +1,41 @@
+import datetime
+import xml.etree.ElementTree as ET
+
+import lofigui as lg
+
+ATOM = "{http://www.w3.org/2005/Atom}"
+
+
+def test_entries_and_updated():
+    items = [
+        lg.FeedItem("Pump on", "http://x/1", "tick", datetime.datetime(2024, 5, 1, 9)),
+        lg.FeedItem("A & B", "http://x/2", "<b>", datetime.datetime(2024, 5, 2, 9)),
+    ]
+    feed = ET.fromstring(lg.atom_feed("Events", "http://x/", items))
+    entries = feed.findall(f"{ATOM}entry")
+    assert [e.find(f"{ATOM}title").text for e in entries] == ["Pump on", "A & B"]
+    assert entries[1].find(f"{ATOM}summary").text == "<b>"
+    assert feed.find(f"{ATOM}updated").text == "2024-05-02T09:00:00Z"
+
+
+def test_mixed_naive_and_aware_times():
+    tz = datetime.timezone(datetime.timedelta(hours=2))
+    items = [
+        lg.FeedItem("a", "http://x/1", "", datetime.datetime(2024, 5, 1, 9)),
+        lg.FeedItem(
+            "b", "http://x/2", "", datetime.datetime(2024, 5, 1, 12, tzinfo=tz)
+        ),
+    ]
+    feed = ET.fromstring(lg.atom_feed("Events", "http://x/", items))
+    assert feed.find(f"{ATOM}updated").text == "2024-05-01T10:00:00Z"
+
+
+def test_author():
+    feed = ET.fromstring(lg.atom_feed("Events", "http://x/", [], author="Plant"))
+    assert feed.find(f"{ATOM}author/{ATOM}name").text == "Plant"
+    feed = ET.fromstring(lg.atom_feed("Events", "http://x/", []))
+    assert feed.find(f"{ATOM}author/{ATOM}name").text == "Events"
+
+
+def test_content_type():
+    assert lg.ATOM_CONTENT_TYPE.startswith("application/atom+xml")
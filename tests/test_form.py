@@ -0,0 +1,50 @@
+import lofigui as lg
+
+
+def test_action_and_method():
+    f = lg.Form("/create", "post")
+    f.text("note", "Note", "What to remember")
+    f.submit("Create")
+    result = f.render()
+    assert result.startswith('<form action="/create" method="post">')
+    assert 'placeholder="What to remember"' in result
+    assert '<button class="button is-primary" type="submit">Create</button>' in result
+
+
+def test_required_attribute():
+    result = lg.Form("/x").text("a", "A", required=True).number("b", "B").render()
+    assert 'name="a" required>' in result
+    assert 'name="b">' in result
+
+
+def test_attributes_escaped():
+    result = lg.Form('/x?a=1&b="2"').text('n"', "<L>").render()
+    assert 'action="/x?a=1&amp;b=&quot;2&quot;"' in result
+    assert 'name="n&quot;"' in result
+    assert "&lt;L&gt;" in result
+
+
+def test_write():
+    ctx = lg.PrintContext()
+    lg.Form("/x").write(ctx)
+    assert lg.buffer(ctx) == '<form action="/x" method="post">\n</form>\n'
+
+
+def test_form_values_valid():
+    fv = lg.FormValues({"note_id": "7", "new_text": " hi "})
+    assert fv.int("note_id") == (7, True)
+    assert fv.non_empty("new_text") == ("hi", True)
+    assert fv.errors() == []
+
+
+def test_form_values_missing():
+    fv = lg.FormValues({"new_text": "   "})
+    assert fv.int("note_id") == (0, False)
+    assert fv.non_empty("new_text") == ("", False)
+    assert fv.errors() == ["note_id is missing", "new_text must not be empty"]
+
+
+def test_form_values_not_a_number():
+    fv = lg.FormValues({"note_id": "seven"})
+    assert fv.int("note_id") == (0, False)
+    assert fv.errors() == ["note_id must be a whole number, not 'seven'"]
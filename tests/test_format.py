@@ -0,0 +1,26 @@
+import datetime
+
+import lofigui as lg
+
+
+def test_format_percent():
+    assert lg.format_percent(50.26) == "50.3%"
+    assert lg.format_percent(12.5, 0) == "12%"
+    assert lg.format_percent(-3.14159, 2) == "-3.14%"
+    assert lg.format_percent(-0.01) == "0.0%"
+
+
+def test_format_thousands():
+    assert lg.format_thousands(0) == "0"
+    assert lg.format_thousands(1234567) == "1,234,567"
+    assert lg.format_thousands(-1000) == "-1,000"
+
+
+def test_format_duration():
+    assert lg.format_duration(datetime.timedelta(0)) == "0s"
+    assert lg.format_duration(125) == "2m 5s"
+    assert lg.format_duration(datetime.timedelta(hours=1, seconds=1.9)) == "1h 1s"
+    assert lg.format_duration(90061) == "1d 1h 1m 1s"
+    assert lg.format_duration(3600) == "1h"
+    assert lg.format_duration(-65) == "-1m 5s"
+    assert lg.format_duration(-0.5) == "0s"
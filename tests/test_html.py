@@ -0,0 +1,51 @@
+import html
+
+import lofigui as lg
+
+
+def test_htmlf_escapes_string_inside_raw_tags():
+    ctx = lg.PrintContext()
+    lg.htmlf("<td>%s</td>", "<b>", ctx=ctx)
+    assert lg.buffer(ctx) == "<td>&lt;b&gt;</td>"
+
+
+def test_htmlf_escapes_non_string_arguments():
+    ctx = lg.PrintContext()
+    lg.htmlf("<td>%s</td>", Exception("<script>x</script>"), ctx=ctx)
+    assert lg.buffer(ctx) == "<td>&lt;script&gt;x&lt;/script&gt;</td>"
+
+
+def test_htmlf_escapes_quotes_inside_attribute():
+    ctx = lg.PrintContext()
+    lg.htmlf('<a href="%s">x</a>', 'x" onmouseover="alert(1)', ctx=ctx)
+    assert lg.buffer(ctx) == '<a href="x&quot; onmouseover=&quot;alert(1)">x</a>'
+
+
+def test_htmlf_numbers_keep_their_format():
+    ctx = lg.PrintContext()
+    lg.htmlf("<td>%d</td><td>%.1f</td><td>%s</td>", 3, 2.25, True, ctx=ctx)
+    assert lg.buffer(ctx) == "<td>3</td><td>2.2</td><td>True</td>"
+
+
+def test_escape_matches_html_escape():
+    s = "<a href=\"x\">Tom & Jerry's</a>"
+    assert lg.escape(s) == html.escape(s, quote=False)
+
+
+def test_escape_attr_quotes():
+    assert lg.escape_attr('say "hi"') == "say &quot;hi&quot;"
+    assert lg.escape_attr("it's") == "it&#x27;s"
+
+
+def test_html_is_not_escaped():
+    ctx = lg.PrintContext()
+    lg.html(f"<b>{lg.escape('<i>')}</b>", ctx=ctx)
+    assert lg.buffer(ctx) == "<b>&lt;i&gt;</b>"
+
+
+def test_html_safe_strips_script_and_onerror():
+    ctx = lg.PrintContext()
+    lg.html_safe(
+        '<p>hi</p><script>alert(1)</script><img src="a.png" onerror="x()">', ctx=ctx
+    )
+    assert lg.buffer(ctx) == '<p>hi</p><img src="a.png">'
@@ -0,0 +1,90 @@
+import datetime
+import json
+
+import lofigui as lg
+
+
+def test_print_and_table_round_trip():
+    ctx = lg.PrintContext()
+    lg.set_format(lg.FORMAT_JSON, ctx)
+    lg.print("Hello", ctx=ctx)
+    lg.table([["a", 1]], header=["Name", "Value"], ctx=ctx)
+    assert json.loads(lg.to_json(ctx)) == [
+        {"type": "print", "text": "Hello"},
+        {"type": "table", "header": ["Name", "Value"], "rows": [["a", "1"]]},
+    ]
+    assert "<p>Hello</p>" in lg.buffer(ctx)
+
+
+def test_html_format_records_nothing():
+    ctx = lg.PrintContext()
+    lg.print("Hello", ctx=ctx)
+    assert lg.to_json(ctx) == "[]"
+
+
+def recorded(fn):
+    ctx = lg.PrintContext()
+    lg.set_format(lg.FORMAT_JSON, ctx)
+    fn(ctx)
+    return json.loads(lg.to_json(ctx))
+
+
+def test_headings_and_key_values():
+    elements = recorded(lambda ctx: lg.key_values([("Level", "50%")], "Tank", ctx=ctx))
+    assert elements == [
+        {"type": "heading", "level": 4, "text": "Tank"},
+        {"type": "table", "header": [], "rows": [["Level", "50%"]]},
+    ]
+    elements = recorded(lambda ctx: lg.heading_html(9, "<i>x</i>", ctx=ctx))
+    assert elements == [{"type": "heading", "level": 6, "html": "<i>x</i>"}]
+
+
+def test_list_markdown_and_code():
+    def render(ctx):
+        lg.list(["a", 2], ordered=True, ctx=ctx)
+        lg.markdown_safe("*hi*", ctx=ctx)
+        lg.markdown_with("a\nb", hard_breaks=True, ctx=ctx)
+        lg.code_block("x = 1", "python", ctx=ctx)
+
+    assert recorded(render) == [
+        {"type": "list", "items": ["a", "2"], "ordered": True},
+        {"type": "markdown", "text": "*hi*", "safe": True},
+        {"type": "markdown", "text": "a\nb", "tables": True, "hard_breaks": True},
+        {"type": "code", "code": "x = 1", "language": "python"},
+    ]
+
+
+def test_log_tabs_and_button():
+    def render(ctx):
+        when = datetime.datetime(2024, 5, 1, 9, 5, 7)
+        lg.log_line("warn", "slow", when=when, ctx=ctx)
+        lg.tabs([lg.TabItem("a", "A", "/a")], "a", ctx=ctx)
+        lg.button("Go", href="/go", ctx=ctx)
+
+    assert recorded(render) == [
+        {"type": "log", "time": "09:05:07", "level": "warn", "message": "slow"},
+        {
+            "type": "tabs",
+            "items": [{"key": "a", "label": "A", "href": "/a"}],
+            "active": "a",
+        },
+        {
+            "type": "button",
+            "label": "Go",
+            "cls": "",
+            "action": None,
+            "method": "post",
+            "href": "/go",
+        },
+    ]
+
+
+def test_table_writer_records_like_table():
+    def streamed(ctx):
+        with lg.table_writer(["Name", "Value"], ctx=ctx) as tw:
+            tw.row(["a", 1])
+
+    def whole(ctx):
+        lg.table([["a", 1]], header=["Name", "Value"], ctx=ctx)
+
+    assert recorded(streamed) == recorded(whole)
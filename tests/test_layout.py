@@ -0,0 +1,58 @@
+import lofigui as lg
+
+
+def test_details_wraps_inner_output():
+    ctx = lg.PrintContext()
+    lg.details("<Pumps>", lambda: lg.html("<b>on</b>", ctx=ctx), ctx=ctx)
+    assert lg.buffer(ctx) == (
+        "<details><summary>&lt;Pumps&gt;</summary>\n<b>on</b></details>\n"
+    )
+
+
+def test_details_open():
+    ctx = lg.PrintContext()
+    lg.details("x", lambda: None, open=True, ctx=ctx)
+    assert lg.buffer(ctx).startswith("<details open>")
+
+
+def test_columns_one_div_per_callback():
+    ctx = lg.PrintContext()
+    lg.columns(lambda: lg.html("a", ctx=ctx), lambda: lg.html("b", ctx=ctx), ctx=ctx)
+    result = lg.buffer(ctx)
+    assert result.count('<div class="column">') == 2
+    assert result.index("a") < result.index("b")
+
+
+def test_columns_sized():
+    ctx = lg.PrintContext()
+    lg.columns_sized([4, 20], lambda: None, lambda: None, lambda: None, ctx=ctx)
+    result = lg.buffer(ctx)
+    assert '<div class="column is-4">' in result
+    assert result.count('<div class="column">') == 2
+
+
+def test_modal_and_trigger_share_id():
+    ctx = lg.PrintContext()
+    lg.modal_trigger("confirm", "Delete", ctx=ctx)
+    lg.modal("confirm", "<Really?>", lambda: lg.html("<p>gone</p>", ctx=ctx), ctx=ctx)
+    result = lg.buffer(ctx)
+    assert '<a class="button" href="#confirm">Delete</a>' in result
+    assert '<div class="modal" id="confirm">' in result
+    assert "&lt;Really?&gt;" in result
+    assert result.index("<p>gone</p>") < result.index("</section>")
+
+
+def test_accordion_only_first_open_panel():
+    ctx = lg.PrintContext()
+    panels = [
+        lg.Panel("<Pumps>", "<b>on</b>"),
+        lg.Panel("Valves", "<i>shut</i>", open=True),
+        lg.Panel("Floats", "ok", open=True),
+    ]
+    lg.accordion(panels, group="diag", ctx=ctx)
+    result = lg.buffer(ctx)
+    assert result.count('<details class="card" name="diag"') == 3
+    assert result.count(" open>") == 1
+    assert result.index(" open>") > result.index("&lt;Pumps&gt;")
+    assert result.index(" open>") < result.index("Valves")
+    assert "<b>on</b>" in result
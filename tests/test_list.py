@@ -0,0 +1,34 @@
+import lofigui as lg
+
+
+def test_list_unordered_by_default():
+    ctx = lg.PrintContext()
+    lg.list(["a", "b"], ctx=ctx)
+    result = lg.buffer(ctx)
+    assert "<ul>" in result and "<ol>" not in result
+    assert "<li>a</li>" in result and "<li>b</li>" in result
+
+
+def test_list_ordered():
+    ctx = lg.PrintContext()
+    lg.list(["a"], ordered=True, ctx=ctx)
+    assert "<ol>\n  <li>a</li>\n</ol>" in lg.buffer(ctx)
+
+
+def test_list_empty_emits_nothing():
+    ctx = lg.PrintContext()
+    lg.list([], ctx=ctx)
+    lg.list([], ordered=True, ctx=ctx)
+    assert lg.buffer(ctx) == ""
+
+
+def test_list_escapes_items():
+    ctx = lg.PrintContext()
+    lg.list(["a < b"], ctx=ctx)
+    assert "<li>a &lt; b</li>" in lg.buffer(ctx)
+
+
+def test_list_raw_items():
+    ctx = lg.PrintContext()
+    lg.list(["<b>x</b>"], escape=False, ctx=ctx)
+    assert "<li><b>x</b></li>" in lg.buffer(ctx)
@@ -0,0 +1,46 @@
+import datetime
+
+import lofigui as lg
+
+WHEN = datetime.datetime(2024, 5, 1, 9, 5, 7)
+
+
+def test_level_colors():
+    ctx = lg.PrintContext()
+    lg.log_line("info", "started", when=WHEN, ctx=ctx)
+    lg.log_line("warn", "slow", when=WHEN, ctx=ctx)
+    lg.log_line("error", "failed", when=WHEN, ctx=ctx)
+    result = lg.buffer(ctx)
+    assert '<p class="has-text-info"><code>09:05:07</code> started</p>' in result
+    assert 'class="has-text-warning"' in result
+    assert 'class="has-text-danger"' in result
+
+
+def test_unknown_level_is_info():
+    ctx = lg.PrintContext()
+    lg.log_line("debug", "x", when=WHEN, ctx=ctx)
+    assert 'class="has-text-info"' in lg.buffer(ctx)
+
+
+def test_message_escaped():
+    ctx = lg.PrintContext()
+    lg.log_line("info", "<b>", when=WHEN, ctx=ctx)
+    assert "&lt;b&gt;" in lg.buffer(ctx)
+
+
+def test_log_table():
+    ctx = lg.PrintContext()
+    lg.log_line("warn", "float tripped", when=WHEN, ctx=ctx)
+    lg.reset(ctx)
+    lg.log_line("info", "pump on", when=WHEN, ctx=ctx)
+    lg.log_table(ctx)
+    result = lg.buffer(ctx)
+    assert "<td>pump on</td>" in result
+    assert "<td>float tripped</td>" not in result
+
+
+def test_message_accepts_any_value():
+    ctx = lg.PrintContext()
+    lg.log_line("info", 42, when=WHEN, ctx=ctx)
+    assert "</code> 42</p>" in lg.buffer(ctx)
+    assert ctx.log_lines == [("09:05:07", "info", "42")]
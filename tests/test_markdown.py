@@ -0,0 +1,89 @@
+import lofigui as lg
+
+
+def test_markdown_safe_strips_script():
+    ctx = lg.PrintContext()
+    lg.markdown_safe("hello <script>alert(1)</script>", ctx=ctx)
+    assert "<script>" not in lg.buffer(ctx)
+    assert "hello" in lg.buffer(ctx)
+
+
+def test_markdown_keeps_script():
+    ctx = lg.PrintContext()
+    lg.markdown("hello <script>alert(1)</script>", ctx=ctx)
+    assert "<script>alert(1)</script>" in lg.buffer(ctx)
+
+
+TABLE = "| a |\n|--:|\n| 1 |"
+
+
+def test_markdown_table_is_bulma():
+    ctx = lg.PrintContext()
+    lg.markdown(TABLE, ctx=ctx)
+    result = lg.buffer(ctx)
+    assert '<table class="table is-bordered is-striped">' in result
+    assert '<th class="has-text-right">a</th>' in result
+
+
+def test_markdown_safe_keeps_table_styling():
+    ctx = lg.PrintContext()
+    lg.markdown_safe(TABLE, ctx=ctx)
+    result = lg.buffer(ctx)
+    assert '<table class="table is-bordered is-striped">' in result
+    assert '<th class="has-text-right">a</th>' in result
+    assert "<td>1</td>" in result
+
+
+def test_html_safe_filters_table_classes():
+    ctx = lg.PrintContext()
+    cell = '<tr><td class="is-hidden">x</td></tr>'
+    lg.html_safe(f'<table class="table modal is-active">{cell}</table>', ctx=ctx)
+    result = lg.buffer(ctx)
+    assert '<table class="table">' in result
+    assert "<td>x</td>" in result
+
+
+def test_custom_renderer_is_used():
+    ctx = lg.PrintContext()
+    lg.set_markdown_renderer(lambda text: f"<p>{text.upper()}</p>", ctx=ctx)
+    lg.markdown("hello", ctx=ctx)
+    lg.markdown_safe("<script>x</script>safe", ctx=ctx)
+    assert lg.buffer(ctx) == "<p>HELLO</p><p>SAFE</p>"
+
+
+def test_renderer_reset_to_default():
+    ctx = lg.PrintContext()
+    lg.set_markdown_renderer(str.upper, ctx=ctx)
+    lg.set_markdown_renderer(None, ctx=ctx)
+    lg.markdown("hello", ctx=ctx)
+    assert "HELLO" not in lg.buffer(ctx)
+
+
+def test_markdown_with_tables_by_default():
+    ctx = lg.PrintContext()
+    lg.markdown_with(TABLE, ctx=ctx)
+    expected = lg.PrintContext()
+    lg.markdown(TABLE, ctx=expected)
+    assert lg.buffer(ctx) == lg.buffer(expected)
+
+
+def test_markdown_with_tables_off():
+    ctx = lg.PrintContext()
+    lg.markdown_with(TABLE, tables=False, ctx=ctx)
+    assert "<table" not in lg.buffer(ctx)
+
+
+def test_markdown_with_hard_breaks():
+    ctx = lg.PrintContext()
+    lg.markdown_with("a\nb", hard_breaks=True, ctx=ctx)
+    assert "a<br />" in lg.buffer(ctx)
+    ctx = lg.PrintContext()
+    lg.markdown_with("a\nb", ctx=ctx)
+    assert "<br" not in lg.buffer(ctx)
+
+
+def test_markdown_with_uses_custom_renderer():
+    ctx = lg.PrintContext()
+    lg.set_markdown_renderer(str.upper, ctx=ctx)
+    lg.markdown_with("hi", hard_breaks=True, ctx=ctx)
+    assert lg.buffer(ctx) == "HI"
@@ -0,0 +1,70 @@
+import pytest
+
+import lofigui as lg
+
+
+def test_append_is_default():
+    ctx = lg.PrintContext()
+    lg.print("a", ctx=ctx)
+    lg.print("b", ctx=ctx)
+    assert lg.buffer(ctx) == "<p>a</p>\n<p>b</p>\n"
+
+
+def test_prepend_puts_newest_first():
+    ctx = lg.PrintContext()
+    lg.set_mode(lg.MODE_PREPEND, ctx=ctx)
+    lg.print("first", ctx=ctx)
+    lg.print("second", ctx=ctx)
+    assert lg.buffer(ctx) == "<p>second</p>\n<p>first</p>\n"
+    lg.print("third", ctx=ctx)
+    assert lg.buffer(ctx).startswith("<p>third</p>\n<p>second</p>")
+
+
+def test_replace_keeps_only_latest():
+    ctx = lg.PrintContext()
+    lg.set_mode(lg.MODE_REPLACE, ctx=ctx)
+    lg.print("a", ctx=ctx)
+    lg.print("b", ctx=ctx)
+    assert lg.buffer(ctx) == "<p>b</p>\n"
+
+
+def test_output_before_mode_change_keeps_old_placement():
+    ctx = lg.PrintContext()
+    lg.print("a", ctx=ctx)
+    lg.set_mode(lg.MODE_PREPEND, ctx=ctx)
+    lg.print("b", ctx=ctx)
+    assert lg.buffer(ctx) == "<p>b</p>\n<p>a</p>\n"
+
+
+def test_unknown_mode_rejected():
+    with pytest.raises(ValueError):
+        lg.set_mode("sideways", ctx=lg.PrintContext())
+
+
+def test_multi_piece_helpers_need_append():
+    for mode in (lg.MODE_PREPEND, lg.MODE_REPLACE):
+        ctx = lg.PrintContext()
+        lg.set_mode(mode, ctx=ctx)
+        for call in (
+            lambda: lg.capture(lambda: None, ctx=ctx),
+            lambda: lg.checkpoint(ctx=ctx),
+            lambda: lg.rollback(0, ctx=ctx),
+            lambda: lg.render_once(lambda: None, ctx=ctx),
+            lambda: lg.region("x", lambda: None, ctx=ctx),
+            lambda: lg.details("x", lambda: None, ctx=ctx),
+            lambda: lg.columns(lambda: None, ctx=ctx),
+            lambda: lg.modal("m", "x", lambda: None, ctx=ctx),
+            lambda: lg.table_writer(["a"], ctx=ctx),
+            lambda: print("<svg>", "</svg>", file=lg.writer(ctx)),
+            lambda: lg.key_values([("a", "1")], title="t", ctx=ctx),
+        ):
+            with pytest.raises(ValueError):
+                call()
+        assert lg.buffer(ctx) == ""
+
+
+def test_key_values_without_title_in_any_mode():
+    ctx = lg.PrintContext()
+    lg.set_mode(lg.MODE_REPLACE, ctx=ctx)
+    lg.key_values([("a", "1")], ctx=ctx)
+    assert "<td>a</td>" in lg.buffer(ctx)
@@ -0,0 +1,61 @@
+import asyncio
+import threading
+import time
+
+import lofigui as lg
+
+
+def test_put_fires_listener_once():
+    ctx = lg.PrintContext()
+    calls = []
+    fired = threading.Event()
+
+    def listener():
+        calls.append(1)
+        fired.set()
+
+    lg.on_change(listener, ctx)
+    lg.html("<p>hi</p>", ctx=ctx)
+    assert fired.wait(1)
+    time.sleep(0.05)
+    assert calls == [1]
+
+
+def test_register_none_is_noop():
+    ctx = lg.PrintContext()
+    lg.on_change(None, ctx)
+    assert ctx.listeners == []
+    lg.html("<p>hi</p>", ctx=ctx)
+
+
+def test_burst_in_event_loop_is_coalesced():
+    calls = []
+
+    async def render():
+        ctx = lg.PrintContext()
+        lg.on_change(lambda: calls.append(1), ctx)
+        for i in range(3):
+            lg.html(f"<p>{i}</p>", ctx=ctx)
+        await asyncio.sleep(0)
+
+    # A private loop, asyncio.run would clear the current loop that asyncio.Queue
+    # needs on Python 3.7 to 3.9
+    loop = asyncio.new_event_loop()
+    try:
+        loop.run_until_complete(render())
+    finally:
+        loop.close()
+    assert calls == [1]
+
+
+def test_failing_listener_does_not_stop_others():
+    ctx = lg.PrintContext()
+    fired = threading.Event()
+
+    def broken():
+        raise RuntimeError("boom")
+
+    lg.on_change(broken, ctx)
+    lg.on_change(fired.set, ctx)
+    lg.html("<p>hi</p>", ctx=ctx)
+    assert fired.wait(1)
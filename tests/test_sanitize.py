@@ -0,0 +1,36 @@
+from lofigui.sanitize import sanitize
+
+
+def test_script_and_content_removed():
+    assert sanitize("<p>hi <script>alert(1)</script>there</p>") == "<p>hi there</p>"
+
+
+def test_event_handlers_removed():
+    assert sanitize('<img src="a.png" onerror="alert(1)">') == '<img src="a.png">'
+
+
+def test_javascript_urls_removed():
+    assert sanitize('<a href="javascript:alert(1)">x</a>') == "<a>x</a>"
+    assert sanitize('<a href="/ok">x</a>') == '<a href="/ok">x</a>'
+
+
+def test_self_closing_drop_tag_keeps_later_content():
+    assert sanitize("<script/>hello <b>world</b>") == "hello <b>world</b>"
+    assert sanitize("<iframe/><style/>ok") == "ok"
+
+
+def test_unmatched_closers_dropped():
+    assert sanitize("</div></div>hi") == "hi"
+    assert sanitize("<p>a</div>b</p>") == "<p>ab</p>"
+
+
+def test_unclosed_tags_closed_at_end():
+    assert sanitize("<div><b>bold") == "<div><b>bold</b></div>"
+
+
+def test_misnested_tags_closed_in_order():
+    assert sanitize("<b><i>x</b>y") == "<b><i>x</i></b>y"
+
+
+def test_text_escaped():
+    assert sanitize("1 &lt; 2 & 3") == "1 &lt; 2 &amp; 3"
@@ -0,0 +1,23 @@
+import lofigui as lg
+from lofigui import StatusLevel
+
+
+def test_level_classes_and_colors():
+    assert lg.status_class(StatusLevel.OK) == "is-success"
+    assert lg.status_class(StatusLevel.WARNING) == "is-warning"
+    assert lg.status_class(StatusLevel.DANGER) == "is-danger"
+    assert lg.status_color(StatusLevel.OK) == "#48c78e"
+    assert lg.status_color(StatusLevel.WARNING) == "#ffe08a"
+    assert lg.status_color(StatusLevel.DANGER) == "#f14668"
+
+
+def test_status_tag():
+    ctx = lg.PrintContext()
+    lg.status_tag(StatusLevel.DANGER, "<float>", ctx=ctx)
+    assert lg.buffer(ctx) == '<span class="tag is-danger">&lt;float&gt;</span>\n'
+
+
+def test_status_tag_accepts_any_value():
+    ctx = lg.PrintContext()
+    lg.status_tag(StatusLevel.OK, 3.5, ctx=ctx)
+    assert lg.buffer(ctx) == '<span class="tag is-success">3.5</span>\n'
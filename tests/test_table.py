@@ -0,0 +1,182 @@
+import dataclasses
+
+import pytest
+
+import lofigui as lg
+
+
+@dataclasses.dataclass
+class Person:
+    name: str = dataclasses.field(metadata={"lofigui": "Full <name>"})
+    age: int = 0
+    _secret: str = "hidden"
+
+
+def test_table_objects_headers_from_metadata_and_names():
+    ctx = lg.PrintContext()
+    lg.table_objects([Person("Ann", 3)], ctx=ctx)
+    result = lg.buffer(ctx)
+    assert "<th>Full &lt;name&gt;</th>" in result
+    assert "<th>age</th>" in result
+    assert "<td>Ann</td>" in result and "<td>3</td>" in result
+
+
+def test_table_objects_skips_underscore_fields():
+    ctx = lg.PrintContext()
+    lg.table_objects([Person("Ann", 3)], ctx=ctx)
+    assert "hidden" not in lg.buffer(ctx)
+    assert "_secret" not in lg.buffer(ctx)
+
+
+def test_table_objects_escapes_values():
+    ctx = lg.PrintContext()
+    lg.table_objects([Person("<b>x</b>", 3)], ctx=ctx)
+    assert "<td>&lt;b&gt;x&lt;/b&gt;</td>" in lg.buffer(ctx)
+
+
+def test_table_objects_rejects_non_list():
+    with pytest.raises(TypeError):
+        lg.table_objects(Person("Ann"), ctx=lg.PrintContext())
+
+
+def test_table_objects_rejects_non_dataclass_rows():
+    with pytest.raises(TypeError):
+        lg.table_objects([1, 2], ctx=lg.PrintContext())
+    with pytest.raises(TypeError):
+        lg.table_objects([Person("Ann"), "Bob"], ctx=lg.PrintContext())
+
+
+def test_column_align_classes():
+    ctx = lg.PrintContext()
+    lg.table(
+        [["a", "1", "x"]],
+        header=["n", "v", "c"],
+        align=["left", "right", "center"],
+        ctx=ctx,
+    )
+    result = lg.buffer(ctx)
+    assert '<th class="has-text-right">v</th>' in result
+    assert '<td class="has-text-left">a</td>' in result
+    assert '<td class="has-text-right">1</td>' in result
+    assert '<td class="has-text-centered">x</td>' in result
+
+
+def test_short_align_defaults_to_left():
+    ctx = lg.PrintContext()
+    lg.table([["a", "1"]], align=["right"], ctx=ctx)
+    result = lg.buffer(ctx)
+    assert '<td class="has-text-right">a</td>' in result
+    assert "<td>1</td>" in result
+
+
+def test_long_align_ignored():
+    ctx = lg.PrintContext()
+    lg.table([["a"]], align=["right", "center", "left", "bogus"], ctx=ctx)
+    assert lg.buffer(ctx).count("<td") == 1
+
+
+def test_raw_and_escaped_cells_in_one_row():
+    ctx = lg.PrintContext()
+    row = [lg.Cell("<b>x</b>"), lg.Cell('<span class="tag">on</span>', raw=True)]
+    lg.table_cells([row], ctx=ctx)
+    result = lg.buffer(ctx)
+    assert "<td>&lt;b&gt;x&lt;/b&gt;</td>" in result
+    assert '<td><span class="tag">on</span></td>' in result
+
+
+def test_plain_strings_are_escaped_cells():
+    ctx = lg.PrintContext()
+    lg.table_cells([["<i>"]], header=["<h>"], ctx=ctx)
+    result = lg.buffer(ctx)
+    assert "<th>&lt;h&gt;</th>" in result
+    assert "<td>&lt;i&gt;</td>" in result
+
+
+def test_table_writer_matches_table():
+    header = ["Name", "Value"]
+    rows = [["a", "1"], ["b", "2"]]
+    expected = lg.PrintContext()
+    lg.table(rows, header=header, align=["left", "right"], ctx=expected)
+    ctx = lg.PrintContext()
+    with lg.table_writer(header, ctx=ctx, align=["left", "right"]) as tw:
+        for row in rows:
+            tw.row(row)
+    assert lg.buffer(ctx) == lg.buffer(expected)
+
+
+def test_empty_table_writer_matches_table():
+    expected = lg.PrintContext()
+    lg.table([], header=["Name"], ctx=expected)
+    ctx = lg.PrintContext()
+    lg.table_writer(["Name"], ctx=ctx).close()
+    assert lg.buffer(ctx) == lg.buffer(expected)
+
+
+def test_format_applies_to_one_column():
+    ctx = lg.PrintContext()
+    lg.table_cells(
+        [["1.234", "1.234"], ["<b>", "2"]],
+        formats={1: lambda v: f"{float(v):.1f}"},
+        ctx=ctx,
+    )
+    result = lg.buffer(ctx)
+    assert "<td>1.234</td>\n      <td>1.2</td>" in result
+    assert "&lt;b&gt;" in result
+    assert "<td>2.0</td>" in result
+
+
+def test_empty_message_spans_header():
+    ctx = lg.PrintContext()
+    lg.table([], header=["a", "b", "c"], empty_message="No <notes>", ctx=ctx)
+    assert '<td colspan="3">No &lt;notes&gt;</td>' in lg.buffer(ctx)
+
+
+def test_empty_without_message_has_no_body():
+    ctx = lg.PrintContext()
+    lg.table([], header=["a"], ctx=ctx)
+    result = lg.buffer(ctx)
+    assert "<tbody>" not in result
+    assert "colspan" not in result
+
+
+def test_caption_and_footer_positions():
+    ctx = lg.PrintContext()
+    lg.table_cells(
+        [["1", "1"], ["2", "3"]],
+        header=["n", "cumulative"],
+        caption="Fibonacci <sums>",
+        footer=["Total", "<4>"],
+        ctx=ctx,
+    )
+    result = lg.buffer(ctx)
+    assert "<caption>Fibonacci &lt;sums&gt;</caption>" in result
+    assert "<th>&lt;4&gt;</th>" in result
+    order = ["<caption>", "<thead>", "<tbody>", "<tfoot>", "</table>"]
+    positions = [result.index(tag) for tag in order]
+    assert positions == sorted(positions)
+
+
+def test_table_with_matches_keyword_calls():
+    data = [["a", "1"], ["<b>", "2"]]
+    cfg = lg.TableConfig(
+        header=["Name", "Value"],
+        align=["left", "right"],
+        caption="Totals",
+        footer=["All", "3"],
+        empty_message="None",
+    )
+    for escape, render in ((True, lg.table_cells), (False, lg.table)):
+        expected = lg.PrintContext()
+        render(
+            data,
+            header=cfg.header,
+            align=cfg.align,
+            caption=cfg.caption,
+            footer=cfg.footer,
+            empty_message=cfg.empty_message,
+            ctx=expected,
+        )
+        ctx = lg.PrintContext()
+        cfg.escape = escape
+        lg.table_with(data, cfg, ctx=ctx)
+        assert lg.buffer(ctx) == lg.buffer(expected)
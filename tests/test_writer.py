@@ -0,0 +1,38 @@
+import lofigui as lg
+
+
+def test_write_str():
+    ctx = lg.PrintContext()
+    w = lg.writer(ctx)
+    assert w.write("<p>hi</p>") == 9
+    assert lg.buffer(ctx) == "<p>hi</p>"
+
+
+def test_write_bytes_returns_byte_count():
+    ctx = lg.PrintContext()
+    w = lg.writer(ctx)
+    assert w.write("é".encode("utf-8")) == 2
+    assert lg.buffer(ctx) == "é"
+
+
+def test_character_split_across_writes():
+    ctx = lg.PrintContext()
+    w = lg.writer(ctx)
+    data = "a€b".encode("utf-8")
+    assert w.write(data[:2]) == 2
+    assert w.write(data[2:]) == 3
+    assert lg.buffer(ctx) == "a€b"
+
+
+def test_print_to_writer():
+    ctx = lg.PrintContext()
+    print("<b>x</b>", file=lg.writer(ctx))
+    assert lg.buffer(ctx) == "<b>x</b>\n"
+
+
+def test_chunks_in_order():
+    ctx = lg.PrintContext()
+    w = lg.writer(ctx)
+    for chunk in ["<svg>", b"<g/>", "</svg>"]:
+        w.write(chunk)
+    assert lg.buffer(ctx) == "<svg><g/></svg>"